@@ -1,15 +1,21 @@
 package api
 
 import (
+	"aws-ses-sender-go/cmd"
 	"aws-ses-sender-go/config"
 	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/sesevent"
+	"aws-ses-sender-go/pkg/sns"
+	"aws-ses-sender-go/pkg/tracking"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	"image/png"
 	"log"
+	"maps"
 	"net/http"
 	"net/mail"
 	"strconv"
@@ -20,17 +26,54 @@ import (
 	"gorm.io/gorm"
 )
 
+// recipient 수신자 (문자열 주소 또는 {email, vars} 객체)
+type recipient struct {
+	Email string            `json:"email"`
+	Vars  map[string]string `json:"vars"`
+}
+
+// UnmarshalJSON 문자열 주소와 객체 형식을 모두 허용
+func (rc *recipient) UnmarshalJSON(data []byte) error {
+	var email string
+	if err := json.Unmarshal(data, &email); err == nil {
+		*rc = recipient{Email: email}
+		return nil
+	}
+	type plain recipient
+	return json.Unmarshal(data, (*plain)(rc))
+}
+
+// encodeVars 템플릿 변수를 JSON 문자열로 변환 (변수가 없으면 빈 문자열)
+func encodeVars(vars map[string]string) (string, error) {
+	if len(vars) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(vars)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode vars: %w", err)
+	}
+	return string(b), nil
+}
+
 // createMessageHandler 이메일 발송 요청을 받아 처리
 func createMessageHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 
 	var reqBody struct {
 		Messages []struct {
-			TopicId     string   `json:"topicId"`
-			Emails      []string `json:"emails"`
-			Subject     string   `json:"subject"`
-			Content     string   `json:"content"`
-			ScheduledAt string   `json:"scheduledAt"`
+			TopicId         string            `json:"topicId"`
+			Category        string            `json:"category"`
+			Emails          []recipient       `json:"emails"`
+			Subject         string            `json:"subject"`
+			Content         string            `json:"content"`
+			Text            string            `json:"text"`
+			TemplateId      string            `json:"templateId"`
+			TemplateVersion int               `json:"templateVersion"`
+			Vars            map[string]string `json:"vars"`
+			Strict          bool              `json:"strict"`
+			NoClickTracking bool              `json:"noClickTracking"`
+			ScheduledAt     string            `json:"scheduledAt"`
+			Priority        string            `json:"priority"`
 		} `json:"messages"`
 	}
 
@@ -64,49 +107,132 @@ func createMessageHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		trimmedSubject := strings.TrimSpace(msg.Subject)
-		if trimmedSubject == "" {
-			writeError(w, r, http.StatusBadRequest, "subject cannot be empty")
+		trimmedContent := strings.TrimSpace(msg.Content)
+		trimmedText := strings.TrimSpace(msg.Text)
+
+		// 저장된 템플릿을 사용하면 제목과 본문을 템플릿에서 가져옴
+		var tpl *model.Template
+		if msg.TemplateId != "" {
+			if trimmedSubject != "" || trimmedContent != "" || trimmedText != "" {
+				writeError(w, r, http.StatusBadRequest, "templateId cannot be combined with subject, content or text")
+				return
+			}
+			found, err := model.FindTemplate(db, msg.TemplateId, msg.TemplateVersion)
+			if err != nil {
+				if errors.Is(err, model.ErrTemplateNotFound) {
+					writeError(w, r, http.StatusBadRequest, fmt.Sprintf("template not found: %s (version %d)", msg.TemplateId, msg.TemplateVersion))
+					return
+				}
+				writeError(w, r, http.StatusInternalServerError, err.Error())
+				return
+			}
+			tpl = found
+		} else {
+			if trimmedSubject == "" {
+				writeError(w, r, http.StatusBadRequest, "subject cannot be empty")
+				return
+			}
+			if trimmedContent == "" {
+				writeError(w, r, http.StatusBadRequest, "content cannot be empty")
+				return
+			}
+			if err := cmd.ValidateContent(trimmedSubject, trimmedContent, trimmedText); err != nil {
+				writeError(w, r, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		if len(msg.Emails) == 0 {
+			writeError(w, r, http.StatusBadRequest, "emails array cannot be empty")
 			return
 		}
 
-		trimmedContent := strings.TrimSpace(msg.Content)
-		if trimmedContent == "" {
-			writeError(w, r, http.StatusBadRequest, "content cannot be empty")
+		priority, err := model.ParsePriority(msg.Priority)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		if len(msg.Emails) == 0 {
-			writeError(w, r, http.StatusBadRequest, "emails array cannot be empty")
+		// 템플릿 변수 기본값에 메시지 기본 변수를 덮어씀
+		msgVars := make(map[string]string)
+		if tpl != nil {
+			maps.Copy(msgVars, tpl.DefaultVars())
+		}
+		maps.Copy(msgVars, msg.Vars)
+		defaultVars, err := encodeVars(msgVars)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		validEmails := make([]string, 0, len(msg.Emails))
-		for _, email := range msg.Emails {
-			trimmedEmail := strings.TrimSpace(email)
+		validEmails := make([]recipient, 0, len(msg.Emails))
+		for _, rcpt := range msg.Emails {
+			trimmedEmail := strings.TrimSpace(rcpt.Email)
 			if _, err := mail.ParseAddress(trimmedEmail); err != nil {
 				writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid email address: %s", trimmedEmail))
 				return
 			}
-			validEmails = append(validEmails, trimmedEmail)
+			if tpl != nil {
+				vars := maps.Clone(msgVars)
+				maps.Copy(vars, rcpt.Vars)
+				if missing := tpl.MissingVars(vars); len(missing) > 0 {
+					writeError(w, r, http.StatusBadRequest, fmt.Sprintf("missing required variables for %s: %s",
+						trimmedEmail, strings.Join(missing, ", ")))
+					return
+				}
+			}
+			validEmails = append(validEmails, recipient{Email: trimmedEmail, Vars: rcpt.Vars})
 		}
 
-		err := db.Transaction(func(tx *gorm.DB) error {
-			content := &model.Content{
-				Subject: trimmedSubject,
-				Content: trimmedContent,
-			}
-			if err := tx.Create(content).Error; err != nil {
-				return fmt.Errorf("failed to create content: %w", err)
+		// 지정한 카테고리 또는 토픽이 속한 카테고리로 분류 (수신자 구독 설정 확인용)
+		category, err := resolveCategory(msg.TopicId, msg.Category)
+		if err != nil {
+			writeCategoryError(w, r, err)
+			return
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			var content *model.Content
+			if tpl != nil {
+				// 같은 템플릿 버전의 컨텐츠는 재사용
+				found, err := model.TemplateContent(tx, tpl, model.Content{
+					DefaultVars:     defaultVars,
+					Strict:          msg.Strict,
+					NoClickTracking: msg.NoClickTracking,
+				})
+				if err != nil {
+					return err
+				}
+				content = found
+			} else {
+				content = &model.Content{
+					Subject:         trimmedSubject,
+					Content:         trimmedContent,
+					Text:            trimmedText,
+					DefaultVars:     defaultVars,
+					Strict:          msg.Strict,
+					NoClickTracking: msg.NoClickTracking,
+				}
+				if err := tx.Create(content).Error; err != nil {
+					return fmt.Errorf("failed to create content: %w", err)
+				}
 			}
 
 			reqs := make([]*model.Request, 0, len(validEmails))
-			for _, email := range validEmails {
+			for _, rcpt := range validEmails {
+				vars, err := encodeVars(rcpt.Vars)
+				if err != nil {
+					return err
+				}
 				req := &model.Request{
 					TopicId:     msg.TopicId,
-					To:          email,
+					Category:    category,
+					To:          rcpt.Email,
+					Vars:        vars,
 					ContentId:   content.ID,
 					ScheduledAt: &scheduledAt,
 					Status:      model.EmailMsgStatusCreated,
+					Priority:    priority,
 				}
 				reqs = append(reqs, req)
 			}
@@ -139,6 +265,15 @@ func createMessageHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// verifyTracking 추적 URL 서명 검증 (테스트에서 교체 가능)
+var verifyTracking = cmd.VerifyTracking
+
+// recordOpen 열람 이벤트 기록 (테스트에서 교체 가능)
+var recordOpen = cmd.RecordOpen
+
+// recordClick 클릭 이벤트 기록 (테스트에서 교체 가능)
+var recordClick = cmd.RecordClick
+
 // createOpenEventHandler 이메일 열람 추적 이벤트 처리
 func createOpenEventHandler(w http.ResponseWriter, r *http.Request) {
 	reqId := r.URL.Query().Get("requestId")
@@ -161,23 +296,59 @@ func createOpenEventHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	reqIdInt, err := strconv.Atoi(reqId)
-	if err != nil {
+	if err != nil || reqIdInt <= 0 {
 		log.Printf("Invalid requestId format: %s, error: %v", reqId, err)
 		return
 	}
 
-	db := config.GetDB()
-	result := &model.Result{
-		RequestId: uint(reqIdInt),
-		Status:    "Open",
-		Raw:       "{}",
+	// 서명이 없거나 변조된 요청은 픽셀만 반환하고 기록하지 않음
+	if !verifyTracking(tracking.EventOpen, uint(reqIdInt), r.URL.Query().Get("sig")) {
+		return
 	}
 
-	if err := db.Create(result).Error; err != nil {
+	if err := recordOpen(uint(reqIdInt), r.UserAgent(), tracking.ClientIP(r.RemoteAddr)); err != nil {
 		log.Printf("Failed to create open event for requestId %d: %v", reqIdInt, err)
 	}
 }
 
+// createClickEventHandler 링크 클릭 추적 이벤트 기록 후 원래 URL로 리다이렉트
+// 서명이 유효하지 않으면 기록하지 않고 리다이렉트만 수행
+func createClickEventHandler(w http.ResponseWriter, r *http.Request) {
+	reqId, err := strconv.Atoi(r.URL.Query().Get("requestId"))
+	if err != nil || reqId <= 0 {
+		writeError(w, r, http.StatusNotFound, "link not found")
+		return
+	}
+	link, err := strconv.Atoi(r.URL.Query().Get("link"))
+	if err != nil || link < 0 {
+		writeError(w, r, http.StatusNotFound, "link not found")
+		return
+	}
+
+	url, err := recordClick(uint(reqId), link, r.URL.Query().Get("sig"))
+	if err != nil {
+		if errors.Is(err, cmd.ErrLinkNotFound) {
+			writeError(w, r, http.StatusNotFound, "link not found")
+			return
+		}
+		log.Printf("Failed to resolve click link (requestId=%d, link=%d): %v", reqId, link, err)
+		writeError(w, r, http.StatusInternalServerError, "failed to resolve link")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// verifySNS SNS 메시지 서명 검증 (테스트에서 교체 가능)
+var verifySNS = cmd.VerifySNS
+
+// handleSubscription SNS 구독 확인/해지 처리 (테스트에서 교체 가능)
+var handleSubscription = cmd.HandleSubscription
+
+// recordSESEvent SES 이벤트 파싱 및 결과 저장 (테스트에서 교체 가능)
+var recordSESEvent = cmd.RecordSESEvent
+
 // createResultEventHandler AWS SES 이벤트 결과 처리
 func createResultEventHandler(w http.ResponseWriter, r *http.Request) {
 	msgType := r.Header.Get("x-amz-sns-message-type")
@@ -186,28 +357,36 @@ func createResultEventHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if msgType != "Notification" && msgType != "SubscriptionConfirmation" {
+	if msgType != sns.TypeNotification && msgType != sns.TypeSubscriptionConfirmation &&
+		msgType != sns.TypeUnsubscribeConfirmation {
 		writeError(w, r, http.StatusBadRequest, "invalid SNS message type")
 		return
 	}
 
-	var reqBody struct {
-		Type         string `json:"Type"`
-		Message      string `json:"Message"`
-		MessageId    string `json:"MessageId"`
-		SubscribeURL string `json:"SubscribeURL"`
-	}
-
+	var reqBody sns.Message
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
 		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("failed to parse SNS message: %v", err))
 		return
 	}
 
-	if reqBody.Type == "SubscriptionConfirmation" {
-		log.Printf("SNS Subscription confirmation required. URL: %s", reqBody.SubscribeURL)
+	// 서명이 유효하고 허용된 토픽에서 온 메시지만 처리
+	if err := verifySNS(r.Context(), &reqBody); err != nil {
+		log.Printf("Rejected SNS message (type=%s, topic=%s): %v", reqBody.Type, reqBody.TopicArn, err)
+		writeError(w, r, http.StatusForbidden, "SNS message verification failed")
+		return
+	}
+
+	if reqBody.Type == sns.TypeSubscriptionConfirmation || reqBody.Type == sns.TypeUnsubscribeConfirmation {
+		sub, err := handleSubscription(r.Context(), &reqBody)
+		if err != nil {
+			log.Printf("Failed to handle SNS %s (topic=%s): %v", reqBody.Type, reqBody.TopicArn, err)
+			writeError(w, r, http.StatusInternalServerError, "failed to handle subscription")
+			return
+		}
 		writeJSON(w, http.StatusOK, map[string]interface{}{
-			"message": "subscription confirmation required",
-			"url":     reqBody.SubscribeURL,
+			"message":      "ok",
+			"topicArn":     sub.TopicArn,
+			"subscription": sub.Status,
 		})
 		return
 	}
@@ -219,59 +398,23 @@ func createResultEventHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var sesNoti struct {
-		NotiType string `json:"notificationType"`
-		Mail     struct {
-			MsgId   string `json:"messageId"`
-			Headers []struct {
-				Name  string `json:"name"`
-				Value string `json:"value"`
-			} `json:"headers"`
-		} `json:"mail"`
-	}
-
-	if err := json.Unmarshal([]byte(reqBody.Message), &sesNoti); err != nil {
-		log.Printf("Failed to parse SES notification: %v", err)
-		writeError(w, r, http.StatusBadRequest, "invalid SES notification format")
-		return
-	}
-
-	if sesNoti.Mail.MsgId == "" {
-		writeError(w, r, http.StatusBadRequest, "SES message_id not found")
-		return
-	}
-
-	log.Printf("Received %s notification for message %s",
-		sesNoti.NotiType, sesNoti.Mail.MsgId)
-
-	var reqId uint
-	for _, header := range sesNoti.Mail.Headers {
-		if strings.EqualFold(header.Name, "X-Request-ID") {
-			reqIdInt, err := strconv.Atoi(header.Value)
-			if err != nil {
-				log.Printf("Invalid X-Request-ID header value: %s, error: %v", header.Value, err)
-				continue
-			}
-			reqId = uint(reqIdInt)
-			break
+	if _, err := recordSESEvent(reqBody.MessageId, reqBody.Message); err != nil {
+		switch {
+		case errors.Is(err, sesevent.ErrInvalidEvent):
+			log.Printf("Failed to parse SES notification: %v", err)
+			writeError(w, r, http.StatusBadRequest, "invalid SES notification format")
+		case errors.Is(err, cmd.ErrMessageIDNotFound):
+			writeError(w, r, http.StatusBadRequest, err.Error())
+		case errors.Is(err, cmd.ErrDuplicateEvent):
+			// SNS 재전송은 이미 처리했으므로 다시 저장하지 않고 성공 응답
+			writeJSON(w, http.StatusOK, map[string]interface{}{"message": "duplicate"})
+		case errors.Is(err, cmd.ErrOrphanEvent):
+			// 고아 이벤트로 보관했으므로 SNS가 재전송하지 않도록 성공 응답
+			writeJSON(w, http.StatusOK, map[string]interface{}{"message": "orphaned"})
+		default:
+			log.Printf("%v", err)
+			writeError(w, r, http.StatusInternalServerError, "failed to save event")
 		}
-	}
-
-	if reqId == 0 {
-		writeError(w, r, http.StatusBadRequest, "X-Request-ID header not found or invalid")
-		return
-	}
-
-	db := config.GetDB()
-	result := &model.Result{
-		RequestId: reqId,
-		Status:    sesNoti.NotiType,
-		Raw:       reqBody.Message,
-	}
-
-	if err := db.Create(result).Error; err != nil {
-		log.Printf("Failed to save SES result event (requestId=%d): %v", reqId, err)
-		writeError(w, r, http.StatusInternalServerError, "failed to save event")
 		return
 	}
 
@@ -296,8 +439,16 @@ func getResultCntHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	if reqCnt == 0 {
 		writeJSON(w, http.StatusOK, map[string]interface{}{
-			"request": map[string]interface{}{"total": 0, "created": 0, "sent": 0, "failed": 0, "stopped": 0},
-			"result":  map[string]interface{}{"total": 0, "statuses": map[string]int{}},
+			"request": map[string]interface{}{
+				"total": 0, "created": 0, "sent": 0, "failed": 0, "stopped": 0,
+				"delivered": 0, "bounced": 0, "softBounced": 0, "complained": 0, "rejected": 0, "delayed": 0,
+				"suppressed": 0, "unsubscribed": 0, "paused": 0,
+			},
+			"result": map[string]interface{}{
+				"total":    0,
+				"statuses": map[string]int{},
+				"opens":    map[string]int{"total": 0, "uniqueHuman": 0, "uniqueMachine": 0},
+			},
 		})
 		return
 	}
@@ -316,11 +467,20 @@ func getResultCntHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	reqCnts := struct {
-		Total   int `json:"total"`
-		Created int `json:"created"`
-		Sent    int `json:"sent"`
-		Failed  int `json:"failed"`
-		Stopped int `json:"stopped"`
+		Total        int `json:"total"`
+		Created      int `json:"created"`
+		Sent         int `json:"sent"` // 발송 후 SES 전달 결과를 아직 받지 못한 요청
+		Failed       int `json:"failed"`
+		Stopped      int `json:"stopped"`
+		Delivered    int `json:"delivered"`
+		Bounced      int `json:"bounced"`
+		SoftBounced  int `json:"softBounced"`
+		Complained   int `json:"complained"`
+		Rejected     int `json:"rejected"`
+		Delayed      int `json:"delayed"`
+		Suppressed   int `json:"suppressed"`
+		Unsubscribed int `json:"unsubscribed"`
+		Paused       int `json:"paused"`
 	}{Total: int(reqCnt)}
 
 	for _, r := range reqResults {
@@ -333,6 +493,24 @@ func getResultCntHandler(w http.ResponseWriter, r *http.Request) {
 			reqCnts.Failed = r.Count
 		case model.EmailMsgStatusStopped:
 			reqCnts.Stopped = r.Count
+		case model.EmailMsgStatusDelivered:
+			reqCnts.Delivered = r.Count
+		case model.EmailMsgStatusBounced:
+			reqCnts.Bounced = r.Count
+		case model.EmailMsgStatusSoftBounced:
+			reqCnts.SoftBounced = r.Count
+		case model.EmailMsgStatusComplained:
+			reqCnts.Complained = r.Count
+		case model.EmailMsgStatusRejected:
+			reqCnts.Rejected = r.Count
+		case model.EmailMsgStatusDelayed:
+			reqCnts.Delayed = r.Count
+		case model.EmailMsgStatusSuppressed:
+			reqCnts.Suppressed = r.Count
+		case model.EmailMsgStatusUnsubscribed:
+			reqCnts.Unsubscribed = r.Count
+		case model.EmailMsgStatusPaused:
+			reqCnts.Paused = r.Count
 		}
 	}
 
@@ -356,10 +534,30 @@ func getResultCntHandler(w http.ResponseWriter, r *http.Request) {
 		resultCounts[r.Status] = r.Count
 	}
 
+	// 열람 통계: 사람이 연 고유 요청 수와 프록시/봇이 연 고유 요청 수를 분리 (분류 이전 이벤트는 사람으로 집계)
+	// 분류 정보가 없는 SES 열람 이벤트는 제외하고 열람 픽셀 요청만 집계
+	var opens struct {
+		Total         int `json:"total"`
+		UniqueHuman   int `json:"uniqueHuman"`
+		UniqueMachine int `json:"uniqueMachine"`
+	}
+	if err := db.Model(&model.Result{}).
+		Select(`COUNT(*) as total,
+			COUNT(DISTINCT CASE WHEN COALESCE(json_extract(raw, '$.kind'), ?) = ? THEN request_id END) as unique_human,
+			COUNT(DISTINCT CASE WHEN COALESCE(json_extract(raw, '$.kind'), ?) <> ? THEN request_id END) as unique_machine`,
+			tracking.KindHuman, tracking.KindHuman, tracking.KindHuman, tracking.KindHuman).
+		Scopes(model.TrackingEvents).
+		Where("request_id IN (?) AND status = ?", subQuery, "Open").
+		Scan(&opens).Error; err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"request": reqCnts,
 		"result": map[string]interface{}{
 			"statuses": resultCounts,
+			"opens":    opens,
 		},
 	})
 }
@@ -378,13 +576,7 @@ func getSentCntHandler(w http.ResponseWriter, r *http.Request) {
 
 	startTime := time.Now().UTC().Add(-time.Duration(hours) * time.Hour)
 
-	db := config.GetDB()
-	var cnt int64
-	err = db.Model(&model.Request{}).
-		Where("updated_at >= ?", startTime).
-		Where("status = ?", model.EmailMsgStatusSent).
-		Count(&cnt).Error
-
+	cnt, err := model.CountSent(config.GetDB(), startTime)
 	if err != nil {
 		log.Printf("Failed to count sent emails: %v", err)
 		writeError(w, r, http.StatusInternalServerError, "failed to retrieve sent count")
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// categoryView 구독 카테고리 응답 (topicId 목록을 배열로 표시)
+type categoryView struct {
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+	Description string    `json:"description"`
+	Topics      []string  `json:"topics"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// newCategoryView 구독 카테고리를 응답 형식으로 변환
+func newCategoryView(c *model.SubscriptionCategory) categoryView {
+	topics, _ := c.TopicList()
+	if topics == nil {
+		topics = []string{}
+	}
+	return categoryView{
+		Name:        c.Name,
+		DisplayName: c.DisplayName,
+		Description: c.Description,
+		Topics:      topics,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// writeCategoryError 구독 카테고리 에러 응답 (등록되지 않은 카테고리는 400)
+func writeCategoryError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, model.ErrCategoryNotFound) {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeError(w, r, http.StatusInternalServerError, err.Error())
+}
+
+// listCategoriesHandler 구독 카테고리 목록 조회
+func listCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	list, err := model.ListCategories(config.GetDB())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	views := make([]categoryView, 0, len(list))
+	for i := range list {
+		views = append(views, newCategoryView(&list[i]))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":      len(views),
+		"categories": views,
+	})
+}
+
+// putCategoryHandler 구독 카테고리 생성 또는 수정
+func putCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		DisplayName string   `json:"displayName"`
+		Description string   `json:"description"`
+		Topics      []string `json:"topics"` // 이 카테고리로 분류할 topicId 목록
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	if !model.ValidCategoryName(name) {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid category name: %s (lowercase letters, digits, '-' and '_', up to 50 characters)", name))
+		return
+	}
+	c := &model.SubscriptionCategory{
+		Name:        name,
+		DisplayName: strings.TrimSpace(body.DisplayName),
+		Description: strings.TrimSpace(body.Description),
+	}
+	c.SetTopics(body.Topics)
+
+	if err := model.SaveCategory(config.GetDB(), c); err != nil {
+		if errors.Is(err, model.ErrCategoryTopicConflict) {
+			writeError(w, r, http.StatusConflict, err.Error())
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, newCategoryView(c))
+}
+
+// deleteCategoryHandler 구독 카테고리 삭제
+func deleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if err := model.DeleteCategory(config.GetDB(), name); err != nil {
+		if errors.Is(err, model.ErrCategoryNotFound) {
+			writeError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":    name,
+		"deleted": true,
+	})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// cancelTopic 토픽 발송 취소 (테스트에서 교체 가능)
+var cancelTopic = cmd.CancelTopic
+
+// pauseTopic 토픽 발송 일시 중지 (테스트에서 교체 가능)
+var pauseTopic = cmd.PauseTopic
+
+// resumeTopic 토픽 발송 재개 (테스트에서 교체 가능)
+var resumeTopic = cmd.ResumeTopic
+
+// cancelRequest 단일 발송 요청 취소 (테스트에서 교체 가능)
+var cancelRequest = cmd.CancelRequest
+
+// topicActionHandler 토픽의 발송 전 요청 상태를 변경하고 변경된 수를 응답하는 핸들러 생성
+func topicActionHandler(action string, apply func(topicId string) (int64, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		topicID := chi.URLParam(r, "topicId")
+		if topicID == "" {
+			writeError(w, r, http.StatusBadRequest, "topicId is required")
+			return
+		}
+
+		affected, err := apply(topicID)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"topicId":  topicID,
+			"action":   action,
+			"affected": affected,
+		})
+	}
+}
+
+// cancelTopicHandler 토픽의 대기, 일시 중지, 발송 대기열에 있는 요청을 중지
+func cancelTopicHandler(w http.ResponseWriter, r *http.Request) {
+	topicActionHandler("cancel", cancelTopic)(w, r)
+}
+
+// pauseTopicHandler 토픽의 대기, 발송 대기열에 있는 요청을 일시 중지
+func pauseTopicHandler(w http.ResponseWriter, r *http.Request) {
+	topicActionHandler("pause", pauseTopic)(w, r)
+}
+
+// resumeTopicHandler 토픽의 일시 중지된 요청을 다시 발송 대기 상태로 전환
+func resumeTopicHandler(w http.ResponseWriter, r *http.Request) {
+	topicActionHandler("resume", resumeTopic)(w, r)
+}
+
+// cancelMessageHandler 단일 발송 요청 취소 (이미 발송되었거나 종료된 요청은 409)
+func cancelMessageHandler(w http.ResponseWriter, r *http.Request) {
+	reqId, err := strconv.ParseUint(chi.URLParam(r, "requestId"), 10, 64)
+	if err != nil || reqId == 0 {
+		writeError(w, r, http.StatusBadRequest, "invalid requestId")
+		return
+	}
+
+	if err := cancelRequest(uint(reqId)); err != nil {
+		switch {
+		case errors.Is(err, cmd.ErrRequestNotFound):
+			writeError(w, r, http.StatusNotFound, err.Error())
+		case errors.Is(err, cmd.ErrRequestNotCancellable):
+			writeError(w, r, http.StatusConflict, err.Error())
+		default:
+			writeError(w, r, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"requestId": reqId,
+		"affected":  1,
+	})
+}
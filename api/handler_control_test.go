@@ -0,0 +1,106 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestTopicActionHandlers 토픽 취소, 일시 중지, 재개 API가 전환된 수를 응답하는지 검증
+func TestTopicActionHandlers(t *testing.T) {
+	origCancel, origPause, origResume := cancelTopic, pauseTopic, resumeTopic
+	t.Cleanup(func() { cancelTopic, pauseTopic, resumeTopic = origCancel, origPause, origResume })
+
+	var calls []string
+	stub := func(action string, n int64) func(string) (int64, error) {
+		return func(topicId string) (int64, error) {
+			calls = append(calls, action+"/"+topicId)
+			return n, nil
+		}
+	}
+	cancelTopic, pauseTopic, resumeTopic = stub("cancel", 3), stub("pause", 2), stub("resume", 1)
+
+	tests := []struct {
+		name         string           // 테스트 케이스 이름
+		handler      http.HandlerFunc // 호출할 핸들러
+		wantContains []string         // 응답 본문에 포함되어야 할 문자열
+		wantCall     string           // 예상 제어 함수 호출 (action/topicId)
+	}{
+		{"토픽 취소", cancelTopicHandler, []string{`"action":"cancel"`, `"affected":3`}, "cancel/promo"},
+		{"토픽 일시 중지", pauseTopicHandler, []string{`"action":"pause"`, `"affected":2`}, "pause/promo"},
+		{"토픽 재개", resumeTopicHandler, []string{`"action":"resume"`, `"affected":1`}, "resume/promo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			req := httptest.NewRequest(http.MethodPost, "/v1/topics/promo/action", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("topicId", "promo")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+			tt.handler(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("상태 코드 = %d, 예상 = %d (본문: %s)", rr.Code, http.StatusOK, rr.Body.String())
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rr.Body.String(), want) {
+					t.Errorf("응답 본문에 %q 없음: %s", want, rr.Body.String())
+				}
+			}
+			if len(calls) != 1 || calls[0] != tt.wantCall {
+				t.Errorf("호출 = %v, 예상 = [%s]", calls, tt.wantCall)
+			}
+		})
+	}
+}
+
+// TestCancelMessageHandler 단일 요청 취소 API의 에러별 상태 코드 검증
+func TestCancelMessageHandler(t *testing.T) {
+	orig := cancelRequest
+	t.Cleanup(func() { cancelRequest = orig })
+	cancelRequest = func(requestID uint) error {
+		switch requestID {
+		case 1:
+			return nil
+		case 2:
+			return fmt.Errorf("%w (status=2)", cmd.ErrRequestNotCancellable)
+		case 3:
+			return errors.New("database is locked")
+		}
+		return cmd.ErrRequestNotFound
+	}
+
+	tests := []struct {
+		name       string // 테스트 케이스 이름
+		requestId  string // 경로의 요청 ID
+		wantStatus int    // 예상 HTTP 상태 코드
+	}{
+		{"발송 전 요청 취소", "1", http.StatusOK},
+		{"이미 발송된 요청", "2", http.StatusConflict},
+		{"DB 에러", "3", http.StatusInternalServerError},
+		{"없는 요청", "4", http.StatusNotFound},
+		{"잘못된 요청 ID", "abc", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/v1/messages/"+tt.requestId, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("requestId", tt.requestId)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+			cancelMessageHandler(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("상태 코드 = %d, 예상 = %d (본문: %s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+		})
+	}
+}
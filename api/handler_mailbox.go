@@ -0,0 +1,65 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/pkg/mailer"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// getMailbox 캡처 메일함 조회 (테스트에서 교체 가능)
+var getMailbox = cmd.GetMailbox
+
+// listMailboxHandler 캡처된 메시지 목록 조회 (MAIL_PROVIDER=file, memory)
+func listMailboxHandler(w http.ResponseWriter, r *http.Request) {
+	mb, ok := getMailbox()
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "mailbox is only available with MAIL_PROVIDER=file or memory")
+		return
+	}
+
+	list, err := mb.List()
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":    len(list),
+		"messages": list,
+	})
+}
+
+// getMailboxMessageHandler 캡처된 메시지 원문(.eml) 조회 (format=json이면 요약 정보 포함)
+func getMailboxMessageHandler(w http.ResponseWriter, r *http.Request) {
+	mb, ok := getMailbox()
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "mailbox is only available with MAIL_PROVIDER=file or memory")
+		return
+	}
+
+	info, raw, err := mb.Get(chi.URLParam(r, "messageId"))
+	if err != nil {
+		if errors.Is(err, mailer.ErrMessageNotFound) {
+			writeError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"message": info,
+			"raw":     string(raw),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "message/rfc822")
+	w.Header().Set("Content-Disposition", `inline; filename="`+info.ID+`.eml"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(raw)
+}
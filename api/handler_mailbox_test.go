@@ -0,0 +1,114 @@
+package api
+
+import (
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// withMailbox 테스트 동안 캡처 메일함 교체
+func withMailbox(t *testing.T, mb mailer.Mailbox) {
+	t.Helper()
+	orig := getMailbox
+	getMailbox = func() (mailer.Mailbox, bool) { return mb, mb != nil }
+	t.Cleanup(func() { getMailbox = orig })
+}
+
+// TestMailboxHandlers 캡처 메일함 목록/원문 조회 테스트
+func TestMailboxHandlers(t *testing.T) {
+	sink := mailer.NewMemorySink("sender@example.com", 10)
+	id, err := sink.Send(context.Background(), &mailer.Message{
+		RequestID: 7,
+		To:        []string{"user@example.com"},
+		Subject:   "캡처 테스트",
+		HTML:      "<p>본문</p>",
+	})
+	if err != nil {
+		t.Fatalf("Send() 에러 = %v", err)
+	}
+	withMailbox(t, sink)
+
+	router := chi.NewRouter()
+	router.Get("/v1/mailbox", listMailboxHandler)
+	router.Get("/v1/mailbox/{messageId}", getMailboxMessageHandler)
+
+	tests := []struct {
+		name           string // 테스트 케이스 이름
+		url            string // 요청 URL
+		expectedStatus int    // 예상 HTTP 상태 코드
+		expectedType   string // 예상 Content-Type
+		bodyContains   string // 응답 본문에 포함되어야 할 문자열
+	}{
+		{
+			name:           "메시지 목록 조회",
+			url:            "/v1/mailbox",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+			bodyContains:   id,
+		},
+		{
+			name:           "메시지 원문 조회",
+			url:            "/v1/mailbox/" + id,
+			expectedStatus: http.StatusOK,
+			expectedType:   "message/rfc822",
+			bodyContains:   "X-Request-ID: 7",
+		},
+		{
+			name:           "메시지 JSON 조회",
+			url:            "/v1/mailbox/" + id + "?format=json",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+			bodyContains:   `"requestId":7`,
+		},
+		{
+			name:           "존재하지 않는 메시지",
+			url:            "/v1/mailbox/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("핸들러 상태 코드 = %v, 예상 = %v", rr.Code, tt.expectedStatus)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != tt.expectedType {
+				t.Errorf("Content-Type = %v, 예상 = %v", ct, tt.expectedType)
+			}
+			if tt.bodyContains != "" && !strings.Contains(rr.Body.String(), tt.bodyContains) {
+				t.Errorf("응답 본문에 예상 문자열이 없음. 응답 = %v, 예상 포함 = %v", rr.Body.String(), tt.bodyContains)
+			}
+		})
+	}
+}
+
+// TestMailboxUnavailable 캡처 제공자가 아닌 경우 404 반환
+func TestMailboxUnavailable(t *testing.T) {
+	withMailbox(t, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/mailbox", nil)
+	rr := httptest.NewRecorder()
+	listMailboxHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("핸들러 상태 코드 = %v, 예상 = %v", rr.Code, http.StatusNotFound)
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("JSON 파싱 실패: %v", err)
+	}
+	if _, ok := response["error"]; !ok {
+		t.Error("에러 응답이 예상되었지만 'error' 필드가 없음")
+	}
+}
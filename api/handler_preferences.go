@@ -0,0 +1,215 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/mail"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// preferenceURL 수신자의 서명된 환경 설정 페이지 URL 생성 (테스트에서 교체 가능)
+var preferenceURL = cmd.PreferenceURL
+
+// parsePreferenceToken 환경 설정 페이지 토큰 검증 (테스트에서 교체 가능)
+var parsePreferenceToken = cmd.ParsePreferenceToken
+
+// recipientPreferences 수신자 구독 여부 조회 (테스트에서 교체 가능)
+var recipientPreferences = cmd.RecipientPreferences
+
+// updatePreferences 수신자 구독 여부 저장 (테스트에서 교체 가능)
+var updatePreferences = cmd.UpdatePreferences
+
+// preferencePage 환경 설정 페이지 데이터
+type preferencePage struct {
+	Error      string                   // 오류 안내 (있으면 양식 대신 표시)
+	Email      string                   // 수신 주소
+	Action     string                   // 양식 제출 URL (토큰 포함)
+	Categories []cmd.CategoryPreference // 카테고리별 구독 여부
+	Saved      bool                     // 저장 완료 여부
+}
+
+var preferenceTemplate = template.Must(template.New("preferences").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Email preferences</title>
+</head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto; padding: 0 16px;">
+<h1>Email preferences</h1>
+{{- if .Error}}
+<p>{{.Error}}</p>
+{{- else}}
+<p>Choose which emails {{.Email}} receives from us.</p>
+{{- if .Saved}}
+<p><strong>Your preferences have been saved.</strong></p>
+{{- end}}
+<form method="post" action="{{.Action}}">
+{{- range .Categories}}
+<p>
+<label><input type="checkbox" name="category" value="{{.Name}}"{{if .Subscribed}} checked{{end}}> <strong>{{.DisplayName}}</strong></label>
+{{- if .Description}}<br><small>{{.Description}}</small>{{end}}
+</p>
+{{- else}}
+<p>There are no email categories to manage.</p>
+{{- end}}
+{{- if .Categories}}
+<p><button type="submit">Save preferences</button></p>
+{{- end}}
+</form>
+{{- end}}
+</body>
+</html>
+`))
+
+// writePreferencePage 환경 설정 페이지 응답
+func writePreferencePage(w http.ResponseWriter, status int, page preferencePage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := preferenceTemplate.Execute(w, page); err != nil {
+		log.Printf("Failed to render preference page: %v", err)
+	}
+}
+
+// getPreferencesHandler 수신자의 카테고리별 구독 여부와 환경 설정 페이지 URL 조회
+func getPreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	email := model.NormalizeEmail(chi.URLParam(r, "email"))
+	if _, err := mail.ParseAddress(email); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid email address: %s", email))
+		return
+	}
+
+	prefs, err := recipientPreferences(email)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	url, expiresAt := preferenceURL(email)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"email":       email,
+		"preferences": prefs,
+		"url":         url,
+		"expiresAt":   expiresAt,
+	})
+}
+
+// putPreferencesHandler 수신자의 카테고리별 구독 여부 변경 (본문에 없는 카테고리는 유지)
+func putPreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	email := model.NormalizeEmail(chi.URLParam(r, "email"))
+	if _, err := mail.ParseAddress(email); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid email address: %s", email))
+		return
+	}
+
+	var body struct {
+		Preferences map[string]bool `json:"preferences"` // 카테고리 이름별 구독 여부
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if len(body.Preferences) == 0 {
+		writeError(w, r, http.StatusBadRequest, "preferences cannot be empty")
+		return
+	}
+
+	if err := updatePreferences(email, body.Preferences); err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+	prefs, err := recipientPreferences(email)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"email":       email,
+		"preferences": prefs,
+	})
+}
+
+// preferenceTokenEmail 환경 설정 페이지 토큰을 검증하고 오류 페이지 응답 (유효하지 않으면 false)
+func preferenceTokenEmail(w http.ResponseWriter, r *http.Request) (string, bool) {
+	email, err := parsePreferenceToken(r.URL.Query().Get("token"))
+	if err != nil {
+		message := "This preferences link is invalid."
+		if errors.Is(err, cmd.ErrPreferenceTokenExpired) {
+			message = "This preferences link has expired."
+		}
+		writePreferencePage(w, http.StatusForbidden, preferencePage{Error: message})
+		return "", false
+	}
+	return email, true
+}
+
+// getPreferencePageHandler 토큰으로 인증하는 환경 설정 페이지
+func getPreferencePageHandler(w http.ResponseWriter, r *http.Request) {
+	email, ok := preferenceTokenEmail(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := recipientPreferences(email)
+	if err != nil {
+		log.Printf("Failed to load preferences for %s: %v", email, err)
+		writePreferencePage(w, http.StatusInternalServerError, preferencePage{Error: "We could not load your preferences. Please try again later."})
+		return
+	}
+	writePreferencePage(w, http.StatusOK, preferencePage{
+		Email:      email,
+		Action:     r.URL.RequestURI(),
+		Categories: prefs,
+	})
+}
+
+// postPreferencePageHandler 환경 설정 페이지 양식 저장 (선택한 카테고리는 구독, 나머지는 구독 해지)
+func postPreferencePageHandler(w http.ResponseWriter, r *http.Request) {
+	email, ok := preferenceTokenEmail(w, r)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writePreferencePage(w, http.StatusBadRequest, preferencePage{Error: "Invalid form submission."})
+		return
+	}
+
+	current, err := recipientPreferences(email)
+	if err != nil {
+		log.Printf("Failed to load preferences for %s: %v", email, err)
+		writePreferencePage(w, http.StatusInternalServerError, preferencePage{Error: "We could not save your preferences. Please try again later."})
+		return
+	}
+	checked := make(map[string]bool, len(r.PostForm["category"]))
+	for _, name := range r.PostForm["category"] {
+		checked[name] = true
+	}
+	prefs := make(map[string]bool, len(current))
+	for _, c := range current {
+		prefs[c.Name] = checked[c.Name]
+	}
+
+	if len(prefs) > 0 {
+		if err := updatePreferences(email, prefs); err != nil {
+			log.Printf("Failed to save preferences for %s: %v", email, err)
+			writePreferencePage(w, http.StatusInternalServerError, preferencePage{Error: "We could not save your preferences. Please try again later."})
+			return
+		}
+	}
+	for i := range current {
+		current[i].Subscribed = prefs[current[i].Name]
+	}
+	writePreferencePage(w, http.StatusOK, preferencePage{
+		Email:      email,
+		Action:     r.URL.RequestURI(),
+		Categories: current,
+		Saved:      true,
+	})
+}
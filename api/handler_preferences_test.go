@@ -0,0 +1,178 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/model"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// stubPreferences 환경 설정 핸들러 의존성을 테스트용으로 교체 (토큰 "ok"만 유효, "expired"는 만료)
+func stubPreferences(t *testing.T) *map[string]bool {
+	t.Helper()
+	origURL, origParse, origList, origUpdate := preferenceURL, parsePreferenceToken, recipientPreferences, updatePreferences
+	t.Cleanup(func() {
+		preferenceURL, parsePreferenceToken, recipientPreferences, updatePreferences = origURL, origParse, origList, origUpdate
+	})
+
+	saved := map[string]bool{"marketing": false}
+	preferenceURL = func(email string) (string, time.Time) {
+		return "https://mail.example.com/v1/preferences?token=ok", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	parsePreferenceToken = func(token string) (string, error) {
+		switch token {
+		case "ok":
+			return "user@example.com", nil
+		case "expired":
+			return "", cmd.ErrPreferenceTokenExpired
+		}
+		return "", fmt.Errorf("invalid token")
+	}
+	recipientPreferences = func(email string) ([]cmd.CategoryPreference, error) {
+		list := make([]cmd.CategoryPreference, 0, 2)
+		for _, name := range []string{"billing", "marketing"} {
+			subscribed, ok := saved[name]
+			list = append(list, cmd.CategoryPreference{Name: name, DisplayName: strings.ToUpper(name), Subscribed: !ok || subscribed})
+		}
+		return list, nil
+	}
+	updatePreferences = func(email string, prefs map[string]bool) error {
+		for name, subscribed := range prefs {
+			if name != "billing" && name != "marketing" {
+				return fmt.Errorf("%w: %s", model.ErrCategoryNotFound, name)
+			}
+			saved[name] = subscribed
+		}
+		return nil
+	}
+	return &saved
+}
+
+// TestPreferencePageHandlers 토큰 인증 환경 설정 페이지 표시와 양식 저장 테스트
+func TestPreferencePageHandlers(t *testing.T) {
+	tests := []struct {
+		name         string          // 테스트 케이스 이름
+		method       string          // HTTP 메서드
+		token        string          // 쿼리 토큰
+		body         string          // 양식 본문
+		wantStatus   int             // 예상 HTTP 상태 코드
+		wantContains string          // 응답 본문에 포함되어야 할 문자열
+		wantSaved    map[string]bool // 저장 후 예상 구독 여부 (nil이면 확인하지 않음)
+	}{
+		{
+			name:         "구독 중인 카테고리만 선택된 양식 표시",
+			method:       http.MethodGet,
+			token:        "ok",
+			wantStatus:   http.StatusOK,
+			wantContains: `value="billing" checked> <strong>BILLING</strong>`,
+			wantSaved:    map[string]bool{"marketing": false},
+		},
+		{
+			name:         "선택한 카테고리는 구독, 나머지는 구독 해지",
+			method:       http.MethodPost,
+			token:        "ok",
+			body:         "category=marketing",
+			wantStatus:   http.StatusOK,
+			wantContains: "Your preferences have been saved.",
+			wantSaved:    map[string]bool{"billing": false, "marketing": true},
+		},
+		{
+			name:         "잘못된 토큰",
+			method:       http.MethodPost,
+			token:        "bad",
+			body:         "category=marketing",
+			wantStatus:   http.StatusForbidden,
+			wantContains: "This preferences link is invalid.",
+			wantSaved:    map[string]bool{"marketing": false},
+		},
+		{
+			name:         "만료된 토큰",
+			method:       http.MethodGet,
+			token:        "expired",
+			wantStatus:   http.StatusForbidden,
+			wantContains: "This preferences link has expired.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := stubPreferences(t)
+
+			req := httptest.NewRequest(tt.method, "/v1/preferences?token="+tt.token, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			if tt.method == http.MethodGet {
+				getPreferencePageHandler(rr, req)
+			} else {
+				postPreferencePageHandler(rr, req)
+			}
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("상태 코드 = %d, 예상 = %d (본문: %s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), tt.wantContains) {
+				t.Errorf("응답 본문에 %q 없음: %s", tt.wantContains, rr.Body.String())
+			}
+			if tt.wantSaved == nil {
+				return
+			}
+			if len(*saved) != len(tt.wantSaved) {
+				t.Fatalf("저장된 구독 여부 = %v, 예상 = %v", *saved, tt.wantSaved)
+			}
+			for name, want := range tt.wantSaved {
+				if (*saved)[name] != want {
+					t.Errorf("저장된 구독 여부 = %v, 예상 = %v", *saved, tt.wantSaved)
+				}
+			}
+		})
+	}
+}
+
+// TestPreferencesAPIHandlers API 키로 수신자 구독 여부를 조회하고 변경하는 API 테스트
+func TestPreferencesAPIHandlers(t *testing.T) {
+	tests := []struct {
+		name         string // 테스트 케이스 이름
+		method       string // HTTP 메서드
+		email        string // 경로의 수신 주소
+		body         string // 요청 본문
+		wantStatus   int    // 예상 HTTP 상태 코드
+		wantContains string // 응답 본문에 포함되어야 할 문자열
+	}{
+		{"구독 여부와 환경 설정 URL 조회", http.MethodGet, "User@example.com", "", http.StatusOK, `"url":"https://mail.example.com/v1/preferences?token=ok"`},
+		{"잘못된 이메일 주소", http.MethodGet, "invalid", "", http.StatusBadRequest, "invalid email address"},
+		{"구독 여부 변경", http.MethodPut, "user@example.com", `{"preferences":{"billing":false}}`, http.StatusOK, `{"name":"billing","displayName":"BILLING","description":"","subscribed":false}`},
+		{"등록되지 않은 카테고리", http.MethodPut, "user@example.com", `{"preferences":{"unknown":false}}`, http.StatusBadRequest, "subscription category not found"},
+		{"빈 변경 목록", http.MethodPut, "user@example.com", `{"preferences":{}}`, http.StatusBadRequest, "preferences cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubPreferences(t)
+
+			req := httptest.NewRequest(tt.method, "/v1/preferences/"+tt.email, strings.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("email", tt.email)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+			if tt.method == http.MethodGet {
+				getPreferencesHandler(rr, req)
+			} else {
+				putPreferencesHandler(rr, req)
+			}
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("상태 코드 = %d, 예상 = %d (본문: %s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), tt.wantContains) {
+				t.Errorf("응답 본문에 %q 없음: %s", tt.wantContains, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/mailer"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// sendNow 즉시 발송 처리 (테스트에서 교체 가능)
+var sendNow = cmd.SendNow
+
+// resolveCategory 발송 요청의 구독 카테고리 결정 (테스트에서 교체 가능)
+var resolveCategory = cmd.ResolveCategory
+
+// sendMessageHandler 단건 이메일을 스케줄러를 거치지 않고 즉시 발송 (OTP, 로그인 메일 등)
+func sendMessageHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	var reqBody struct {
+		TopicId         string            `json:"topicId"`
+		Category        string            `json:"category"`
+		Email           string            `json:"email"`
+		Subject         string            `json:"subject"`
+		Content         string            `json:"content"`
+		Vars            map[string]string `json:"vars"`
+		Strict          bool              `json:"strict"`
+		NoClickTracking bool              `json:"noClickTracking"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	email := strings.TrimSpace(reqBody.Email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid email address: %s", email))
+		return
+	}
+	subject := strings.TrimSpace(reqBody.Subject)
+	if subject == "" {
+		writeError(w, r, http.StatusBadRequest, "subject cannot be empty")
+		return
+	}
+	content := strings.TrimSpace(reqBody.Content)
+	if content == "" {
+		writeError(w, r, http.StatusBadRequest, "content cannot be empty")
+		return
+	}
+
+	if err := cmd.ValidateContent(subject, content, ""); err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	vars, err := encodeVars(reqBody.Vars)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	category, err := resolveCategory(reqBody.TopicId, reqBody.Category)
+	if err != nil {
+		writeCategoryError(w, r, err)
+		return
+	}
+
+	result, err := sendNow(r.Context(),
+		&model.Content{Subject: subject, Content: content, Strict: reqBody.Strict, NoClickTracking: reqBody.NoClickTracking},
+		&model.Request{TopicId: reqBody.TopicId, Category: category, To: email, Vars: vars, Priority: model.EmailPriorityHigh},
+	)
+	if err != nil {
+		if result == nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, cmd.ErrSendPaused) {
+				status = http.StatusServiceUnavailable
+			}
+			writeError(w, r, status, err.Error())
+			return
+		}
+		writeSendError(w, r, result, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"requestId": result.RequestID,
+		"messageId": result.MessageID,
+		"status":    result.Status,
+		"elapsed":   time.Since(start).String(),
+	})
+}
+
+// writeSendError 저장된 요청의 발송 실패 응답 (요청 ID와 에러 분류 포함)
+func writeSendError(w http.ResponseWriter, r *http.Request, result *cmd.SendResult, err error) {
+	body := map[string]interface{}{
+		"error":     err.Error(),
+		"requestId": result.RequestID,
+		"status":    result.Status,
+		"path":      r.URL.Path,
+		"method":    r.Method,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	// 발송 슬롯을 얻지 못한 요청은 비동기 발송으로 전환되었으므로 접수 응답
+	if errors.Is(err, cmd.ErrSendQueued) {
+		writeJSON(w, http.StatusAccepted, body)
+		return
+	}
+
+	// 수신 거부 목록에 있거나 수신자가 수신 거부한 주소는 요청만 저장하고 발송하지 않음
+	if errors.Is(err, cmd.ErrRecipientSuppressed) || errors.Is(err, cmd.ErrRecipientUnsubscribed) {
+		writeJSON(w, http.StatusUnprocessableEntity, body)
+		return
+	}
+
+	status := http.StatusBadGateway
+	if mailer.IsTransient(err) {
+		status = http.StatusServiceUnavailable
+	}
+	body["kind"] = mailer.KindOf(err).String()
+	writeJSON(w, status, body)
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/mailer"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// withSendNow 테스트 동안 즉시 발송 처리 교체
+func withSendNow(t *testing.T, fn func(context.Context, *model.Content, *model.Request) (*cmd.SendResult, error)) {
+	t.Helper()
+	orig, origResolve := sendNow, resolveCategory
+	sendNow = fn
+	resolveCategory = stubResolveCategory
+	t.Cleanup(func() { sendNow, resolveCategory = orig, origResolve })
+}
+
+// stubResolveCategory 테스트용 카테고리 결정 (marketing 카테고리와 promo 토픽만 등록된 것으로 처리)
+func stubResolveCategory(topicId, category string) (string, error) {
+	switch {
+	case category == "marketing", category == "" && topicId == "promo":
+		return "marketing", nil
+	case category != "":
+		return "", fmt.Errorf("%w: %s", model.ErrCategoryNotFound, category)
+	}
+	return "", nil
+}
+
+// TestSendMessageHandler 즉시 발송 API 응답 검증
+func TestSendMessageHandler(t *testing.T) {
+	validBody := map[string]interface{}{
+		"email":   "user@example.com",
+		"subject": "인증 코드",
+		"content": "<p>123456</p>",
+	}
+
+	tests := []struct {
+		name           string                 // 테스트 케이스 이름
+		body           map[string]interface{} // 요청 본문
+		result         *cmd.SendResult        // 즉시 발송 결과
+		err            error                  // 즉시 발송 에러
+		expectedStatus int                    // 예상 HTTP 상태 코드
+		expectedFields map[string]interface{} // 응답에 포함되어야 할 필드
+		wantCategory   string                 // 발송 요청에 저장될 카테고리
+	}{
+		{
+			name:           "발송 성공 시 메시지 ID 반환",
+			body:           validBody,
+			result:         &cmd.SendResult{RequestID: 1, MessageID: "ses-id", Status: "sent"},
+			expectedStatus: http.StatusOK,
+			expectedFields: map[string]interface{}{"messageId": "ses-id", "requestId": float64(1), "status": "sent"},
+		},
+		{
+			name:           "잘못된 이메일 주소",
+			body:           map[string]interface{}{"email": "invalid", "subject": "s", "content": "c"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "빈 제목",
+			body:           map[string]interface{}{"email": "user@example.com", "subject": " ", "content": "c"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "잘못된 템플릿 문법",
+			body:           map[string]interface{}{"email": "user@example.com", "subject": "인증 코드", "content": "<p>{{.code</p>"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "영구 에러는 502와 에러 분류 반환",
+			body:           validBody,
+			result:         &cmd.SendResult{RequestID: 2, Status: "failed"},
+			err:            mailer.Permanent(errors.New("MessageRejected")),
+			expectedStatus: http.StatusBadGateway,
+			expectedFields: map[string]interface{}{"kind": "permanent", "requestId": float64(2), "status": "failed"},
+		},
+		{
+			name:           "일시적 에러는 503과 재시도 상태 반환",
+			body:           validBody,
+			result:         &cmd.SendResult{RequestID: 3, Status: "retrying"},
+			err:            mailer.Transient(errors.New("timeout")),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedFields: map[string]interface{}{"kind": "transient", "status": "retrying"},
+		},
+		{
+			name:           "발송 슬롯 대기 취소 시 202 접수",
+			body:           validBody,
+			result:         &cmd.SendResult{RequestID: 4, Status: "queued"},
+			err:            fmt.Errorf("%w: %w", cmd.ErrSendQueued, context.Canceled),
+			expectedStatus: http.StatusAccepted,
+			expectedFields: map[string]interface{}{"requestId": float64(4), "status": "queued"},
+		},
+		{
+			name:           "수신 거부 목록 주소는 422",
+			body:           validBody,
+			result:         &cmd.SendResult{RequestID: 5, Status: "suppressed"},
+			err:            fmt.Errorf("%w: user@example.com (BOUNCE)", cmd.ErrRecipientSuppressed),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: map[string]interface{}{"requestId": float64(5), "status": "suppressed"},
+		},
+		{
+			name:           "수신자가 수신 거부한 주소는 422",
+			body:           validBody,
+			result:         &cmd.SendResult{RequestID: 6, Status: "unsubscribed"},
+			err:            fmt.Errorf("%w: user@example.com (topic)", cmd.ErrRecipientUnsubscribed),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: map[string]interface{}{"requestId": float64(6), "status": "unsubscribed"},
+		},
+		{
+			name:           "토픽이 속한 카테고리로 분류",
+			body:           map[string]interface{}{"topicId": "promo", "email": "user@example.com", "subject": "인증 코드", "content": "c"},
+			result:         &cmd.SendResult{RequestID: 7, Status: "sent"},
+			expectedStatus: http.StatusOK,
+			wantCategory:   "marketing",
+		},
+		{
+			name:           "등록되지 않은 카테고리는 400",
+			body:           map[string]interface{}{"category": "unknown", "email": "user@example.com", "subject": "인증 코드", "content": "c"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "일일 한도 소진 시 503",
+			body:           validBody,
+			err:            cmd.ErrSendPaused,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			withSendNow(t, func(_ context.Context, content *model.Content, req *model.Request) (*cmd.SendResult, error) {
+				called = true
+				if req.To != "user@example.com" || content.Subject != "인증 코드" {
+					t.Errorf("전달된 요청 = %+v, 컨텐츠 = %+v", req, content)
+				}
+				if req.Category != tt.wantCategory {
+					t.Errorf("카테고리 = %q, 예상 = %q", req.Category, tt.wantCategory)
+				}
+				return tt.result, tt.err
+			})
+
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/v1/messages/send", bytes.NewReader(bodyBytes))
+			rr := httptest.NewRecorder()
+			sendMessageHandler(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("상태 코드 = %d, 예상 = %d (본문: %s)", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedStatus == http.StatusBadRequest && called {
+				t.Error("검증 실패 요청이 발송 처리로 전달됨")
+			}
+
+			var response map[string]interface{}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("응답 파싱 실패: %v", err)
+			}
+			for key, want := range tt.expectedFields {
+				if response[key] != want {
+					t.Errorf("응답[%s] = %v, 예상 = %v", key, response[key], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"net/http"
+)
+
+// listSNSSubscriptionsHandler 발송 결과 SNS 토픽 구독 목록과 상태 조회
+func listSNSSubscriptionsHandler(w http.ResponseWriter, r *http.Request) {
+	subs, err := model.ListSNSSubscriptions(config.GetDB())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":         len(subs),
+		"subscriptions": subs,
+	})
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// maxSuppressionImport 일괄 등록 한 번에 받을 수 있는 최대 주소 수
+const maxSuppressionImport = 10000
+
+// suppressionBody 수신 거부 등록 요청 본문
+type suppressionBody struct {
+	Email     string     `json:"email"`
+	Reason    string     `json:"reason"`    // BOUNCE, COMPLAINT, MANUAL (기본값 MANUAL)
+	Detail    string     `json:"detail"`    // 메모
+	ExpiresAt *time.Time `json:"expiresAt"` // 비어 있으면 만료 없음
+}
+
+// toSuppression 요청 본문을 수신 거부 항목으로 변환하고 검증
+func (b *suppressionBody) toSuppression(source string, now time.Time) (*model.Suppression, error) {
+	email := model.NormalizeEmail(b.Email)
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, fmt.Errorf("invalid email address: %s", b.Email)
+	}
+
+	reason := strings.ToUpper(strings.TrimSpace(b.Reason))
+	if reason == "" {
+		reason = model.SuppressionReasonManual
+	}
+	if !model.ValidSuppressionReason(reason) {
+		return nil, fmt.Errorf("invalid reason: %s (must be BOUNCE, COMPLAINT or MANUAL)", b.Reason)
+	}
+	if b.ExpiresAt != nil && !b.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("expiresAt must be in the future")
+	}
+
+	s := &model.Suppression{
+		Email:  email,
+		Reason: reason,
+		Source: source,
+		Detail: strings.TrimSpace(b.Detail),
+	}
+	if b.ExpiresAt != nil {
+		expiresAt := b.ExpiresAt.UTC()
+		s.ExpiresAt = &expiresAt
+	}
+	return s, nil
+}
+
+// listSuppressionsHandler 수신 거부 목록 조회 (reason, limit, offset 파라미터)
+func listSuppressionsHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	reason := strings.ToUpper(strings.TrimSpace(q.Get("reason")))
+	if reason != "" && !model.ValidSuppressionReason(reason) {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid reason: %s", q.Get("reason")))
+		return
+	}
+
+	limit, offset := 100, 0
+	if v := q.Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 || parsed > 1000 {
+			writeError(w, r, http.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+		limit = parsed
+	}
+	if v := q.Get("offset"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			writeError(w, r, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		offset = parsed
+	}
+
+	list, total, err := model.ListSuppressions(config.GetDB(), reason, limit, offset)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total":        total,
+		"count":        len(list),
+		"suppressions": list,
+	})
+}
+
+// createSuppressionHandler 수신 거부 등록 (이미 있으면 사유와 만료 시각 갱신)
+func createSuppressionHandler(w http.ResponseWriter, r *http.Request) {
+	var body suppressionBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	s, err := body.toSuppression(model.SuppressionSourceAPI, time.Now().UTC())
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := model.SaveSuppression(config.GetDB(), s); err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, s)
+}
+
+// deleteSuppressionHandler 수신 거부 해제
+func deleteSuppressionHandler(w http.ResponseWriter, r *http.Request) {
+	email := chi.URLParam(r, "email")
+	if err := model.RemoveSuppression(config.GetDB(), email); err != nil {
+		if errors.Is(err, model.ErrSuppressionNotFound) {
+			writeError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"email":   model.NormalizeEmail(email),
+		"deleted": true,
+	})
+}
+
+// importSuppressionsHandler 수신 거부 일괄 등록 (잘못된 항목은 건너뛰고 목록으로 반환)
+func importSuppressionsHandler(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Suppressions []suppressionBody `json:"suppressions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if len(body.Suppressions) == 0 {
+		writeError(w, r, http.StatusBadRequest, "suppressions cannot be empty")
+		return
+	}
+	if len(body.Suppressions) > maxSuppressionImport {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("too many suppressions (max %d)", maxSuppressionImport))
+		return
+	}
+
+	type importError struct {
+		Index int    `json:"index"`
+		Email string `json:"email"`
+		Error string `json:"error"`
+	}
+	now := time.Now().UTC()
+	valid := make([]*model.Suppression, 0, len(body.Suppressions))
+	invalid := make([]importError, 0)
+	for i, b := range body.Suppressions {
+		s, err := b.toSuppression(model.SuppressionSourceImport, now)
+		if err != nil {
+			invalid = append(invalid, importError{Index: i, Email: b.Email, Error: err.Error()})
+			continue
+		}
+		valid = append(valid, s)
+	}
+
+	err := config.GetDB().Transaction(func(tx *gorm.DB) error {
+		for _, s := range valid {
+			if err := model.SaveSuppression(tx, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"imported": len(valid),
+		"invalid":  invalid,
+	})
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestSuppressionHandlersValidation 수신 거부 API 요청 검증 (DB 접근 전 단계)
+func TestSuppressionHandlersValidation(t *testing.T) {
+	tests := []struct {
+		name          string           // 테스트 케이스 이름
+		handler       http.HandlerFunc // 테스트할 핸들러
+		method        string           // HTTP 메서드
+		url           string           // 요청 URL
+		body          string           // 요청 본문
+		errorContains string           // 에러 메시지에 포함되어야 할 문자열
+	}{
+		{"등록 - 잘못된 JSON 형식", createSuppressionHandler, http.MethodPost, "/v1/suppressions", `invalid json`, "invalid request body"},
+		{"등록 - 주소 없음", createSuppressionHandler, http.MethodPost, "/v1/suppressions", `{"reason":"MANUAL"}`, "email is required"},
+		{"등록 - 잘못된 주소", createSuppressionHandler, http.MethodPost, "/v1/suppressions", `{"email":"not-an-email"}`, "invalid email address"},
+		{"등록 - 지원하지 않는 사유", createSuppressionHandler, http.MethodPost, "/v1/suppressions", `{"email":"a@example.com","reason":"SPAM"}`, "invalid reason"},
+		{"등록 - 지난 만료 시각", createSuppressionHandler, http.MethodPost, "/v1/suppressions", `{"email":"a@example.com","expiresAt":"2000-01-01T00:00:00Z"}`, "expiresAt must be in the future"},
+		{"일괄 등록 - 빈 목록", importSuppressionsHandler, http.MethodPost, "/v1/suppressions/import", `{"suppressions":[]}`, "suppressions cannot be empty"},
+		{"조회 - 지원하지 않는 사유", listSuppressionsHandler, http.MethodGet, "/v1/suppressions?reason=SPAM", "", "invalid reason"},
+		{"조회 - 잘못된 limit", listSuppressionsHandler, http.MethodGet, "/v1/suppressions?limit=5000", "", "limit must be between"},
+		{"조회 - 음수 offset", listSuppressionsHandler, http.MethodGet, "/v1/suppressions?offset=-1", "", "offset must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewReader([]byte(tt.body)))
+			rr := httptest.NewRecorder()
+
+			tt.handler(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("상태 코드 = %d, 예상 = %d", rr.Code, http.StatusBadRequest)
+			}
+			var response map[string]interface{}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("응답 파싱 실패: %v", err)
+			}
+			if msg, _ := response["error"].(string); !strings.Contains(msg, tt.errorContains) {
+				t.Errorf("에러 메시지 = %q, 예상 포함 = %q", msg, tt.errorContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// templateBody 템플릿 생성/수정 요청 본문
+type templateBody struct {
+	Name      string                   `json:"name"`
+	Subject   string                   `json:"subject"`
+	HTML      string                   `json:"html"`
+	Text      string                   `json:"text"`
+	Variables []model.TemplateVariable `json:"variables"`
+}
+
+// decodeTemplate 요청 본문을 템플릿으로 변환하고 검증
+func decodeTemplate(r *http.Request, name string) (*model.Template, error) {
+	var body templateBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if name == "" {
+		name = body.Name
+	}
+
+	tpl := &model.Template{
+		Name:    strings.TrimSpace(name),
+		Subject: strings.TrimSpace(body.Subject),
+		HTML:    strings.TrimSpace(body.HTML),
+		Text:    strings.TrimSpace(body.Text),
+	}
+	if len(body.Variables) > 0 {
+		vars, err := json.Marshal(body.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template variables: %w", err)
+		}
+		tpl.Variables = string(vars)
+	}
+	if err := tpl.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cmd.ValidateContent(tpl.Subject, tpl.HTML, tpl.Text); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// writeTemplateError 템플릿 저장소 에러 응답
+func writeTemplateError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, model.ErrTemplateNotFound):
+		writeError(w, r, http.StatusNotFound, err.Error())
+	case errors.Is(err, model.ErrTemplateExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// createTemplateHandler 새 템플릿 생성 (버전 1)
+func createTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	tpl, err := decodeTemplate(r, "")
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := model.CreateTemplate(config.GetDB(), tpl); err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, tpl)
+}
+
+// listTemplatesHandler 템플릿별 최신 버전 목록 조회
+func listTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	templates, err := model.ListTemplates(config.GetDB())
+	if err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":     len(templates),
+		"templates": templates,
+	})
+}
+
+// getTemplateHandler 템플릿 조회 (version 파라미터가 없으면 최신 버전, 전체 버전 번호 포함)
+func getTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	version := 0
+	if v := r.URL.Query().Get("version"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			writeError(w, r, http.StatusBadRequest, "version must be a positive integer")
+			return
+		}
+		version = parsed
+	}
+
+	db := config.GetDB()
+	tpl, err := model.FindTemplate(db, name, version)
+	if err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+	list, err := model.ListTemplateVersions(db, name)
+	if err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+	versions := make([]int, 0, len(list))
+	for _, v := range list {
+		versions = append(versions, v.Version)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"template": tpl,
+		"versions": versions,
+	})
+}
+
+// publishTemplateHandler 템플릿의 새 버전 발행 (기존 버전과 이미 예약된 발송은 변경되지 않음)
+func publishTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	tpl, err := decodeTemplate(r, chi.URLParam(r, "name"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := model.PublishTemplateVersion(config.GetDB(), tpl); err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tpl)
+}
+
+// deleteTemplateHandler 템플릿 전체 버전 삭제
+func deleteTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if err := model.DeleteTemplate(config.GetDB(), name); err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":    name,
+		"deleted": true,
+	})
+}
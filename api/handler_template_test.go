@@ -0,0 +1,47 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestCreateTemplateHandlerValidation 템플릿 생성 API 입력 검증 테스트
+// DB 연결 전 검증 케이스만 테스트
+func TestCreateTemplateHandlerValidation(t *testing.T) {
+	tests := []struct {
+		name          string // 테스트 케이스 이름
+		body          string // 요청 본문
+		errorContains string // 에러 메시지에 포함되어야 할 문자열
+	}{
+		{"잘못된 JSON 형식", `invalid json`, "invalid request body"},
+		{"이름 없음", `{"subject":"s","html":"<p>h</p>"}`, "template name is required"},
+		{"이름에 경로 문자 포함", `{"name":"a/b","subject":"s","html":"<p>h</p>"}`, "cannot contain"},
+		{"본문 없음", `{"name":"welcome","subject":"s"}`, "html cannot be empty"},
+		{"변수 이름 없음", `{"name":"welcome","subject":"s","html":"h","variables":[{"required":true}]}`, "variable name is required"},
+		{"잘못된 템플릿 문법", `{"name":"welcome","subject":"{{.name","html":"h"}`, "invalid subject template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/templates", bytes.NewReader([]byte(tt.body)))
+			rr := httptest.NewRecorder()
+
+			createTemplateHandler(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("상태 코드 = %d, 예상 = %d", rr.Code, http.StatusBadRequest)
+			}
+			var response map[string]interface{}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("응답 파싱 실패: %v", err)
+			}
+			if msg, _ := response["error"].(string); !strings.Contains(msg, tt.errorContains) {
+				t.Errorf("에러 메시지 = %q, 예상 포함 = %q", msg, tt.errorContains)
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package api
 
 import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/sesevent"
+	"aws-ses-sender-go/pkg/sns"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -29,6 +35,18 @@ func TestCreateOpenEventHandler(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedType:   "image/png",
 		},
+		{
+			name:           "서명이 없는 요청은 기록하지 않고 픽셀 반환",
+			requestID:      "1",
+			expectedStatus: http.StatusOK,
+			expectedType:   "image/png",
+		},
+		{
+			name:           "변조된 서명도 픽셀 반환",
+			requestID:      "1&sig=forged",
+			expectedStatus: http.StatusOK,
+			expectedType:   "image/png",
+		},
 	}
 
 	for _, tt := range tests {
@@ -69,12 +87,68 @@ func TestCreateOpenEventHandler(t *testing.T) {
 	}
 }
 
+// TestCreateClickEventHandler 링크 클릭 추적 핸들러 테스트
+func TestCreateClickEventHandler(t *testing.T) {
+	orig := recordClick
+	t.Cleanup(func() { recordClick = orig })
+	recordClick = func(requestID uint, index int, signature string) (string, error) {
+		if requestID == 1 && index == 0 && signature == "ok" {
+			return "https://example.com/landing?a=1&b=2", nil
+		}
+		return "", cmd.ErrLinkNotFound
+	}
+
+	tests := []struct {
+		name             string // 테스트 케이스 이름
+		query            string // 쿼리 문자열
+		expectedStatus   int    // 예상 HTTP 상태 코드
+		expectedLocation string // 예상 리다이렉트 URL
+	}{
+		{"원래 URL로 리다이렉트", "requestId=1&link=0&sig=ok", http.StatusFound, "https://example.com/landing?a=1&b=2"},
+		{"서명 없는 클릭은 리다이렉트하지 않음", "requestId=1&link=0", http.StatusNotFound, ""},
+		{"변조된 서명", "requestId=1&link=0&sig=forged", http.StatusNotFound, ""},
+		{"없는 링크 번호", "requestId=1&link=3&sig=ok", http.StatusNotFound, ""},
+		{"잘못된 requestId", "requestId=abc&link=0", http.StatusNotFound, ""},
+		{"link 누락", "requestId=1", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/events/click?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			createClickEventHandler(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("핸들러 상태 코드 = %v, 예상 = %v", rr.Code, tt.expectedStatus)
+			}
+			if location := rr.Header().Get("Location"); location != tt.expectedLocation {
+				t.Errorf("Location = %q, 예상 = %q", location, tt.expectedLocation)
+			}
+		})
+	}
+}
+
 // TestCreateResultEventHandler AWS SES 이벤트 결과 핸들러 테스트
 func TestCreateResultEventHandler(t *testing.T) {
+	origVerify, origSubscription, origRecord := verifySNS, handleSubscription, recordSESEvent
+	t.Cleanup(func() {
+		verifySNS, handleSubscription, recordSESEvent = origVerify, origSubscription, origRecord
+	})
+	handleSubscription = func(_ context.Context, msg *sns.Message) (*model.SNSSubscription, error) {
+		status := model.SNSSubscriptionConfirmed
+		if msg.Type == sns.TypeUnsubscribeConfirmation {
+			status = model.SNSSubscriptionUnsubscribed
+		}
+		return &model.SNSSubscription{TopicArn: msg.TopicArn, Status: status}, nil
+	}
+
 	tests := []struct {
 		name           string            // 테스트 케이스 이름
 		messageType    string            // x-amz-sns-message-type 헤더 값
 		requestBody    map[string]string // 요청 본문
+		verifyErr      error             // SNS 서명 검증 결과
+		recordErr      error             // SES 이벤트 저장 결과
 		expectedStatus int               // 예상 HTTP 상태 코드
 		expectError    bool              // 에러 응답 예상 여부
 	}{
@@ -110,6 +184,96 @@ func TestCreateResultEventHandler(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectError:    false,
 		},
+		{
+			name:        "UnsubscribeConfirmation 메시지",
+			messageType: "UnsubscribeConfirmation",
+			requestBody: map[string]string{
+				"Type":         "UnsubscribeConfirmation",
+				"TopicArn":     "arn:aws:sns:us-east-1:123456789012:ses-events",
+				"SubscribeURL": "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+			},
+			expectedStatus: http.StatusOK,
+			expectError:    false,
+		},
+		{
+			name:        "서명 검증 실패",
+			messageType: "Notification",
+			requestBody: map[string]string{
+				"Type":      "Notification",
+				"Message":   `{"notificationType":"Bounce","mail":{"messageId":"forged"}}`,
+				"MessageId": "test-id",
+			},
+			verifyErr:      sns.ErrInvalidSignature,
+			expectedStatus: http.StatusForbidden,
+			expectError:    true,
+		},
+		{
+			name:        "허용되지 않은 토픽",
+			messageType: "SubscriptionConfirmation",
+			requestBody: map[string]string{
+				"Type":         "SubscriptionConfirmation",
+				"TopicArn":     "arn:aws:sns:us-east-1:999999999999:other",
+				"SubscribeURL": "https://sns.amazonaws.com/confirm",
+			},
+			verifyErr:      sns.ErrTopicNotAllowed,
+			expectedStatus: http.StatusForbidden,
+			expectError:    true,
+		},
+		{
+			name:        "반송 알림 저장",
+			messageType: "Notification",
+			requestBody: map[string]string{
+				"Type":    "Notification",
+				"Message": `{"notificationType":"Bounce","mail":{"messageId":"m-1"}}`,
+			},
+			expectedStatus: http.StatusOK,
+			expectError:    false,
+		},
+		{
+			name:        "SES 알림 형식 오류",
+			messageType: "Notification",
+			requestBody: map[string]string{
+				"Type":    "Notification",
+				"Message": "not json",
+			},
+			recordErr:      sesevent.ErrInvalidEvent,
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name:        "요청과 연결되지 않은 이벤트는 보관 후 성공 응답",
+			messageType: "Notification",
+			requestBody: map[string]string{
+				"Type":    "Notification",
+				"Message": `{"notificationType":"Delivery","mail":{"messageId":"m-1"}}`,
+			},
+			recordErr:      cmd.ErrOrphanEvent,
+			expectedStatus: http.StatusOK,
+			expectError:    false,
+		},
+		{
+			name:        "중복 전달된 SNS 메시지는 성공 응답",
+			messageType: "Notification",
+			requestBody: map[string]string{
+				"Type":      "Notification",
+				"MessageId": "sns-1",
+				"Message":   `{"notificationType":"Delivery","mail":{"messageId":"m-1"}}`,
+			},
+			recordErr:      cmd.ErrDuplicateEvent,
+			expectedStatus: http.StatusOK,
+			expectError:    false,
+		},
+		{
+			name:        "이벤트 저장 실패",
+			messageType: "Notification",
+			requestBody: map[string]string{
+				"Type":    "Notification",
+				"Message": `{"notificationType":"Delivery","mail":{"messageId":"m-1"}}`,
+			},
+			recordErr:      errors.New("database is locked"),
+			expectedStatus: http.StatusInternalServerError,
+			expectError:    true,
+		},
 		{
 			name:           "잘못된 JSON 형식의 요청 본문",
 			messageType:    "Notification",
@@ -121,6 +285,14 @@ func TestCreateResultEventHandler(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			verifySNS = func(context.Context, *sns.Message) error { return tt.verifyErr }
+			recordSESEvent = func(string, string) (*model.Result, error) {
+				if tt.recordErr != nil {
+					return nil, tt.recordErr
+				}
+				return &model.Result{}, nil
+			}
+
 			// 요청 본문 생성
 			var bodyBytes []byte
 			if tt.requestBody != nil {
@@ -471,3 +643,26 @@ func TestGetResultCntHandlerValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestRecipientUnmarshalJSON 수신자 문자열/객체 형식 파싱 테스트
+func TestRecipientUnmarshalJSON(t *testing.T) {
+	var recipients []recipient
+	body := `["a@example.com", {"email": "b@example.com", "vars": {"name": "홍길동"}}]`
+	if err := json.Unmarshal([]byte(body), &recipients); err != nil {
+		t.Fatalf("Unmarshal() 에러 = %v", err)
+	}
+
+	if len(recipients) != 2 {
+		t.Fatalf("수신자 수 = %d, 예상 = 2", len(recipients))
+	}
+	if recipients[0].Email != "a@example.com" || recipients[0].Vars != nil {
+		t.Errorf("문자열 수신자 = %+v", recipients[0])
+	}
+	if recipients[1].Email != "b@example.com" || recipients[1].Vars["name"] != "홍길동" {
+		t.Errorf("객체 수신자 = %+v", recipients[1])
+	}
+
+	if err := json.Unmarshal([]byte(`[123]`), &recipients); err == nil {
+		t.Error("숫자 수신자에서 에러를 예상했지만 nil이 반환됨")
+	}
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/model"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// verifyUnsubscribe 수신 거부 URL 서명 검증 (테스트에서 교체 가능)
+var verifyUnsubscribe = cmd.VerifyUnsubscribe
+
+// unsubscribeTarget 수신 거부 대상 발송 요청 조회 (테스트에서 교체 가능)
+var unsubscribeTarget = cmd.UnsubscribeTarget
+
+// unsubscribe 수신 거부 저장 (테스트에서 교체 가능)
+var unsubscribe = cmd.Unsubscribe
+
+// unsubscribeFormMaxMemory 수신 거부 multipart 양식을 메모리에서 처리할 최대 크기
+const unsubscribeFormMaxMemory = 64 << 10
+
+// unsubscribePage 수신 거부 안내 페이지 데이터
+type unsubscribePage struct {
+	Error          string // 오류 안내 (있으면 양식 대신 표시)
+	Email          string // 수신 주소
+	TopicId        string // 수신 거부할 메일의 토픽
+	Action         string // 양식 제출 URL (서명 포함)
+	Done           bool   // 수신 거부 완료 여부
+	Global         bool   // 모든 토픽 수신 거부 여부
+	PreferencesURL string // 카테고리별 구독 설정 페이지 URL
+}
+
+var unsubscribeTemplate = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Unsubscribe</title>
+</head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto; padding: 0 16px;">
+{{- if .Error}}
+<h1>Unsubscribe</h1>
+<p>{{.Error}}</p>
+{{- else if .Done}}
+<h1>You have been unsubscribed</h1>
+<p>{{.Email}} will no longer receive {{if .Global}}any emails{{else}}emails about {{.TopicId}}{{end}} from us.</p>
+{{- else}}
+<h1>Unsubscribe</h1>
+<p>Stop sending emails to {{.Email}}?</p>
+<form method="post" action="{{.Action}}">
+{{- if .TopicId}}
+<p><button type="submit" name="scope" value="topic">Unsubscribe from {{.TopicId}}</button></p>
+{{- end}}
+<p><button type="submit" name="scope" value="global">Unsubscribe from all emails</button></p>
+</form>
+{{- end}}
+{{- if .PreferencesURL}}
+<p><a href="{{.PreferencesURL}}">Manage your email preferences</a></p>
+{{- end}}
+</body>
+</html>
+`))
+
+// writeUnsubscribePage 수신 거부 안내 페이지 응답
+func writeUnsubscribePage(w http.ResponseWriter, status int, page unsubscribePage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := unsubscribeTemplate.Execute(w, page); err != nil {
+		log.Printf("Failed to render unsubscribe page: %v", err)
+	}
+}
+
+// unsubscribeRequestID 수신 거부 URL의 요청 ID와 서명 검증 (유효하지 않으면 false)
+func unsubscribeRequestID(r *http.Request) (uint, bool) {
+	reqId, err := strconv.ParseUint(r.URL.Query().Get("requestId"), 10, 64)
+	if err != nil || reqId == 0 {
+		return 0, false
+	}
+	if !verifyUnsubscribe(uint(reqId), r.URL.Query().Get("sig")) {
+		return 0, false
+	}
+	return uint(reqId), true
+}
+
+// unsubscribeErrorStatus 수신 거부 처리 에러의 HTTP 상태 코드
+func unsubscribeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, cmd.ErrUnsubscribeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, cmd.ErrInvalidUnsubscribeScope):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// unsubscribePreferencesURL 수신 거부 페이지에 표시할 환경 설정 페이지 URL
+func unsubscribePreferencesURL(email string) string {
+	url, _ := preferenceURL(email)
+	return url
+}
+
+// getUnsubscribeHandler 수신 거부 안내 페이지
+// 보안 스캐너가 메일의 링크를 미리 열 수 있으므로 GET 요청은 저장하지 않고 확인 양식만 표시
+func getUnsubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	reqId, ok := unsubscribeRequestID(r)
+	if !ok {
+		writeUnsubscribePage(w, http.StatusForbidden, unsubscribePage{Error: "This unsubscribe link is invalid."})
+		return
+	}
+
+	req, err := unsubscribeTarget(reqId)
+	if err != nil {
+		log.Printf("Failed to find unsubscribe request %d: %v", reqId, err)
+		writeUnsubscribePage(w, unsubscribeErrorStatus(err), unsubscribePage{Error: "This unsubscribe link is no longer available."})
+		return
+	}
+
+	writeUnsubscribePage(w, http.StatusOK, unsubscribePage{
+		Email:          req.To,
+		TopicId:        req.TopicId,
+		Action:         r.URL.RequestURI(),
+		PreferencesURL: unsubscribePreferencesURL(req.To),
+	})
+}
+
+// postUnsubscribeHandler 원클릭 수신 거부 (RFC 8058, List-Unsubscribe=One-Click) 및 안내 페이지 양식 처리
+// 원클릭 요청은 UNSUBSCRIBE_SCOPE 범위로, 양식 요청은 선택한 범위(topic, global)로 저장
+func postUnsubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	reqId, ok := unsubscribeRequestID(r)
+	if !ok {
+		writeError(w, r, http.StatusForbidden, "invalid unsubscribe signature")
+		return
+	}
+	// RFC 8058 원클릭 요청은 multipart/form-data 권장, 안내 페이지 양식은 application/x-www-form-urlencoded
+	err := r.ParseMultipartForm(unsubscribeFormMaxMemory)
+	if errors.Is(err, http.ErrNotMultipart) {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid form body")
+		return
+	}
+
+	if r.PostFormValue("List-Unsubscribe") == "One-Click" {
+		u, err := unsubscribe(reqId, "", model.UnsubscribeMethodOneClick)
+		if err != nil {
+			writeError(w, r, unsubscribeErrorStatus(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"unsubscribed": true,
+			"scope":        u.Scope(),
+		})
+		return
+	}
+
+	u, err := unsubscribe(reqId, r.PostFormValue("scope"), model.UnsubscribeMethodPage)
+	if err != nil {
+		log.Printf("Failed to unsubscribe request %d: %v", reqId, err)
+		writeUnsubscribePage(w, unsubscribeErrorStatus(err), unsubscribePage{Error: "We could not process your request. Please try again later."})
+		return
+	}
+	writeUnsubscribePage(w, http.StatusOK, unsubscribePage{
+		Email:          u.Email,
+		TopicId:        u.TopicId,
+		Done:           true,
+		Global:         u.Scope() == model.UnsubscribeScopeGlobal,
+		PreferencesURL: unsubscribePreferencesURL(u.Email),
+	})
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"aws-ses-sender-go/cmd"
+	"aws-ses-sender-go/model"
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// stubUnsubscribe 수신 거부 핸들러 의존성을 테스트용으로 교체 (요청 1만 유효한 서명 "ok")
+func stubUnsubscribe(t *testing.T) *[]string {
+	t.Helper()
+	origVerify, origTarget, origUnsubscribe := verifyUnsubscribe, unsubscribeTarget, unsubscribe
+	t.Cleanup(func() {
+		verifyUnsubscribe, unsubscribeTarget, unsubscribe = origVerify, origTarget, origUnsubscribe
+	})
+
+	var calls []string
+	verifyUnsubscribe = func(requestID uint, signature string) bool {
+		return signature == "ok"
+	}
+	unsubscribeTarget = func(requestID uint) (*model.Request, error) {
+		if requestID != 1 {
+			return nil, cmd.ErrUnsubscribeNotFound
+		}
+		return &model.Request{To: "user@example.com", TopicId: "newsletter"}, nil
+	}
+	unsubscribe = func(requestID uint, scope, method string) (*model.Unsubscribe, error) {
+		calls = append(calls, scope+"/"+method)
+		if requestID != 1 {
+			return nil, cmd.ErrUnsubscribeNotFound
+		}
+		u := &model.Unsubscribe{Email: "user@example.com", Method: method}
+		if scope != model.UnsubscribeScopeGlobal {
+			u.TopicId = "newsletter"
+		}
+		return u, nil
+	}
+	return &calls
+}
+
+// TestUnsubscribeHandlers 원클릭 수신 거부, 안내 페이지, 양식 제출 테스트
+func TestUnsubscribeHandlers(t *testing.T) {
+	tests := []struct {
+		name         string // 테스트 케이스 이름
+		method       string // HTTP 메서드
+		query        string // 쿼리 문자열
+		body         string // 양식 본문
+		wantStatus   int    // 예상 HTTP 상태 코드
+		wantContains string // 응답 본문에 포함되어야 할 문자열
+		wantCalls    string // 예상 수신 거부 저장 호출 (scope/method, 없으면 빈 값)
+	}{
+		{
+			name:         "원클릭 수신 거부",
+			method:       http.MethodPost,
+			query:        "requestId=1&sig=ok",
+			body:         "List-Unsubscribe=One-Click",
+			wantStatus:   http.StatusOK,
+			wantContains: `"scope":"topic"`,
+			wantCalls:    "/one-click",
+		},
+		{
+			name:         "안내 페이지는 저장하지 않고 양식만 표시",
+			method:       http.MethodGet,
+			query:        "requestId=1&sig=ok",
+			wantStatus:   http.StatusOK,
+			wantContains: `action="/v1/unsubscribe?requestId=1&amp;sig=ok"`,
+		},
+		{
+			name:         "안내 페이지 양식으로 전체 수신 거부",
+			method:       http.MethodPost,
+			query:        "requestId=1&sig=ok",
+			body:         "scope=global",
+			wantStatus:   http.StatusOK,
+			wantContains: "will no longer receive any emails",
+			wantCalls:    "global/page",
+		},
+		{
+			name:         "변조된 서명의 원클릭 요청",
+			method:       http.MethodPost,
+			query:        "requestId=1&sig=bad",
+			body:         "List-Unsubscribe=One-Click",
+			wantStatus:   http.StatusForbidden,
+			wantContains: "invalid unsubscribe signature",
+		},
+		{
+			name:         "변조된 서명의 안내 페이지",
+			method:       http.MethodGet,
+			query:        "requestId=1&sig=bad",
+			wantStatus:   http.StatusForbidden,
+			wantContains: "invalid",
+		},
+		{
+			name:       "없는 요청",
+			method:     http.MethodGet,
+			query:      "requestId=2&sig=ok",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := stubUnsubscribe(t)
+			req := httptest.NewRequest(tt.method, "/v1/unsubscribe?"+tt.query, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			rr := httptest.NewRecorder()
+
+			if tt.method == http.MethodGet {
+				getUnsubscribeHandler(rr, req)
+			} else {
+				postUnsubscribeHandler(rr, req)
+			}
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("상태 코드 = %d, 예상 = %d", rr.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tt.wantContains) {
+				t.Errorf("응답 본문에 %q가 없음: %s", tt.wantContains, rr.Body.String())
+			}
+			if got := strings.Join(*calls, ","); got != tt.wantCalls {
+				t.Errorf("수신 거부 저장 호출 = %q, 예상 = %q", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// TestOneClickUnsubscribeMultipart RFC 8058 권장 형식인 multipart/form-data 원클릭 요청도 원클릭으로 저장
+func TestOneClickUnsubscribeMultipart(t *testing.T) {
+	calls := stubUnsubscribe(t)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.WriteField("List-Unsubscribe", "One-Click"); err != nil {
+		t.Fatalf("양식 필드 작성 실패: %v", err)
+	}
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/unsubscribe?requestId=1&sig=ok", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rr := httptest.NewRecorder()
+	postUnsubscribeHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("상태 코드 = %d, 예상 = %d (본문: %s)", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), `"unsubscribed":true`) {
+		t.Errorf("응답 본문 = %s, 원클릭 응답 예상", rr.Body.String())
+	}
+	if got := strings.Join(*calls, ","); got != "/one-click" {
+		t.Errorf("수신 거부 저장 호출 = %q, 예상 = %q", got, "/one-click")
+	}
+}
@@ -8,9 +8,36 @@ import (
 func setV1Routes(r chi.Router) {
 	r.Route("/v1", func(r chi.Router) {
 		r.Post("/messages", apiKeyAuth(createMessageHandler))
+		r.Post("/messages/send", apiKeyAuth(sendMessageHandler))
+		r.Delete("/messages/{requestId}", apiKeyAuth(cancelMessageHandler))
 		r.Get("/topics/{topicId}", apiKeyAuth(getResultCntHandler))
+		r.Post("/topics/{topicId}/cancel", apiKeyAuth(cancelTopicHandler))
+		r.Post("/topics/{topicId}/pause", apiKeyAuth(pauseTopicHandler))
+		r.Post("/topics/{topicId}/resume", apiKeyAuth(resumeTopicHandler))
 		r.Get("/events/open", createOpenEventHandler)
+		r.Get("/events/click", createClickEventHandler)
 		r.Get("/events/counts/sent", apiKeyAuth(getSentCntHandler))
 		r.Post("/events/results", createResultEventHandler)
+		r.Get("/unsubscribe", getUnsubscribeHandler)
+		r.Post("/unsubscribe", postUnsubscribeHandler)
+		r.Get("/preferences", getPreferencePageHandler)
+		r.Post("/preferences", postPreferencePageHandler)
+		r.Get("/preferences/{email}", apiKeyAuth(getPreferencesHandler))
+		r.Put("/preferences/{email}", apiKeyAuth(putPreferencesHandler))
+		r.Get("/categories", apiKeyAuth(listCategoriesHandler))
+		r.Put("/categories/{name}", apiKeyAuth(putCategoryHandler))
+		r.Delete("/categories/{name}", apiKeyAuth(deleteCategoryHandler))
+		r.Get("/sns/subscriptions", apiKeyAuth(listSNSSubscriptionsHandler))
+		r.Get("/mailbox", apiKeyAuth(listMailboxHandler))
+		r.Get("/mailbox/{messageId}", apiKeyAuth(getMailboxMessageHandler))
+		r.Post("/templates", apiKeyAuth(createTemplateHandler))
+		r.Get("/templates", apiKeyAuth(listTemplatesHandler))
+		r.Get("/templates/{name}", apiKeyAuth(getTemplateHandler))
+		r.Put("/templates/{name}", apiKeyAuth(publishTemplateHandler))
+		r.Delete("/templates/{name}", apiKeyAuth(deleteTemplateHandler))
+		r.Get("/suppressions", apiKeyAuth(listSuppressionsHandler))
+		r.Post("/suppressions", apiKeyAuth(createSuppressionHandler))
+		r.Post("/suppressions/import", apiKeyAuth(importSuppressionsHandler))
+		r.Delete("/suppressions/{email}", apiKeyAuth(deleteSuppressionHandler))
 	})
 }
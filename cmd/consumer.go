@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/aws"
+	"aws-ses-sender-go/pkg/sesevent"
+	"aws-ses-sender-go/pkg/sns"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// eventQueue SES 이벤트 대기열 (SQS, 테스트에서 로컬 대기열로 교체 가능)
+type eventQueue interface {
+	Receive(ctx context.Context) ([]aws.QueueMessage, error)
+	Delete(ctx context.Context, receiptHandle string) error
+}
+
+// eventConsumer SQS 대기열의 SES 이벤트를 SNS 웹훅과 같은 방식으로 검증하고 저장
+type eventConsumer struct {
+	db        *gorm.DB
+	queue     eventQueue
+	verify    func(ctx context.Context, msg *sns.Message) error
+	subscribe func(ctx context.Context, msg *sns.Message) (*model.SNSSubscription, error)
+	backoff   time.Duration // 수신 실패 시 재시도 대기 시간
+}
+
+// RunEventConsumer SQS 대기열에서 SES 이벤트 수신 (SES_EVENTS_QUEUE_URL이 없으면 실행하지 않음)
+// SNS_TOPIC_ARNS가 없으면 모든 SNS 알림이 허용되지 않은 토픽으로 거부되어 삭제되므로 실행하지 않음
+func RunEventConsumer(ctx context.Context) {
+	queueURL := config.GetEnv("SES_EVENTS_QUEUE_URL")
+	if queueURL == "" {
+		return
+	}
+	if len(snsTopicArns()) == 0 {
+		log.Printf("SES event consumer not started: SNS_TOPIC_ARNS is required to accept SNS notifications from %s", queueURL)
+		return
+	}
+
+	queue, err := aws.NewSQSClient(ctx, queueURL)
+	if err != nil {
+		log.Printf("Failed to start SES event consumer: %v", err)
+		return
+	}
+
+	c := &eventConsumer{
+		db:        config.GetDB(),
+		queue:     queue,
+		verify:    VerifySNS,
+		subscribe: HandleSubscription,
+		backoff:   5 * time.Second,
+	}
+	log.Printf("SES event consumer started (queue=%s)", queueURL)
+	c.run(ctx)
+	log.Println("SES event consumer stopped")
+}
+
+// run 종료 신호까지 롱 폴링으로 메시지를 받아 처리
+func (c *eventConsumer) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := c.queue.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to receive SES events: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(c.backoff):
+			}
+			continue
+		}
+
+		for _, m := range messages {
+			c.handle(ctx, m)
+		}
+	}
+}
+
+// handle 메시지를 저장한 뒤에만 대기열에서 삭제 (실패하면 표시 제한 시간 후 다시 수신)
+// 다시 받아도 처리할 수 없는 메시지(형식 오류, 서명 검증 실패)는 재전송하지 않고 삭제
+func (c *eventConsumer) handle(ctx context.Context, m aws.QueueMessage) {
+	if err := c.process(ctx, m); err != nil {
+		if !permanentEventError(err) {
+			log.Printf("Failed to process SQS message %s, leaving it for redelivery: %v", m.MessageId, err)
+			return
+		}
+		log.Printf("Dropping SQS message %s that cannot be processed: %v", m.MessageId, err)
+	}
+
+	// 처리가 끝난 메시지는 종료 신호와 무관하게 삭제
+	deleteCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := c.queue.Delete(deleteCtx, m.ReceiptHandle); err != nil {
+		log.Printf("Failed to delete SQS message %s: %v", m.MessageId, err)
+	}
+}
+
+// process SQS 메시지 본문 처리 (SNS 봉투는 서명 검증 후 Message를, 원본 메시지 전달은 본문을 SES 이벤트로 저장)
+// 저장했거나 이미 처리한 메시지, 고아 이벤트로 보관한 메시지는 nil 반환
+func (c *eventConsumer) process(ctx context.Context, m aws.QueueMessage) error {
+	dedupeId, raw := m.MessageId, m.Body
+
+	var envelope sns.Message
+	if err := json.Unmarshal([]byte(m.Body), &envelope); err == nil && envelope.Type != "" {
+		if err := c.verify(ctx, &envelope); err != nil {
+			return err
+		}
+		switch envelope.Type {
+		case sns.TypeNotification:
+			dedupeId, raw = envelope.MessageId, envelope.Message
+		case sns.TypeSubscriptionConfirmation, sns.TypeUnsubscribeConfirmation:
+			_, err := c.subscribe(ctx, &envelope)
+			return err
+		default:
+			log.Printf("Ignored unsupported SNS message type from SQS: %s", envelope.Type)
+			return nil
+		}
+	}
+
+	_, err := recordSESEvent(c.db, dedupeId, raw)
+	if errors.Is(err, ErrDuplicateEvent) || errors.Is(err, ErrOrphanEvent) {
+		return nil
+	}
+	return err
+}
+
+// permanentEventError 다시 처리해도 같은 결과인 에러 여부 (DB 에러, 인증서 조회 실패 등은 재전송)
+func permanentEventError(err error) bool {
+	return errors.Is(err, sesevent.ErrInvalidEvent) ||
+		errors.Is(err, ErrMessageIDNotFound) ||
+		errors.Is(err, sns.ErrInvalidSignature) ||
+		errors.Is(err, sns.ErrInvalidCertURL) ||
+		errors.Is(err, sns.ErrTopicNotAllowed)
+}
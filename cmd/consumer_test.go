@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/aws"
+	"aws-ses-sender-go/pkg/sns"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeQueue 한 번만 메시지를 돌려주고 종료 신호를 보내는 테스트용 대기열
+type fakeQueue struct {
+	messages []aws.QueueMessage
+	cancel   context.CancelFunc
+	deleted  []string
+}
+
+func (f *fakeQueue) Receive(_ context.Context) ([]aws.QueueMessage, error) {
+	messages := f.messages
+	f.messages = nil
+	if messages == nil {
+		f.cancel()
+	}
+	return messages, nil
+}
+
+func (f *fakeQueue) Delete(_ context.Context, receiptHandle string) error {
+	f.deleted = append(f.deleted, receiptHandle)
+	return nil
+}
+
+// TestEventConsumer SQS 메시지를 저장한 뒤에만 삭제하고, 처리할 수 없는 메시지는 재전송 없이 삭제하는지 테스트
+func TestEventConsumer(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	setMessageID(t, db, req, "m-1")
+	if err := db.Model(req).Update("status", model.EmailMsgStatusSent).Error; err != nil {
+		t.Fatalf("Request 상태 변경 실패: %v", err)
+	}
+
+	sesEvent := func(kind string) string {
+		return fmt.Sprintf(`{"notificationType":"%s","mail":{"messageId":"m-1","headers":[{"name":"X-Request-ID","value":"%d"}]},"delivery":{}}`, kind, req.ID)
+	}
+	envelope := func(msg sns.Message) string {
+		b, _ := json.Marshal(msg)
+		return string(b)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queue := &fakeQueue{cancel: cancel, messages: []aws.QueueMessage{
+		{MessageId: "q-1", ReceiptHandle: "sns-envelope", Body: envelope(sns.Message{
+			Type: sns.TypeNotification, MessageId: "sns-1", Message: sesEvent("Delivery"),
+		})},
+		{MessageId: "q-2", ReceiptHandle: "sns-duplicate", Body: envelope(sns.Message{
+			Type: sns.TypeNotification, MessageId: "sns-1", Message: sesEvent("Delivery"),
+		})},
+		{MessageId: "q-3", ReceiptHandle: "raw-delivery", Body: sesEvent("Delivery")},
+		{MessageId: "q-4", ReceiptHandle: "forged", Body: envelope(sns.Message{
+			Type: sns.TypeNotification, MessageId: "sns-forged", TopicArn: "forged", Message: sesEvent("Bounce"),
+		})},
+		{MessageId: "q-5", ReceiptHandle: "subscription", Body: envelope(sns.Message{
+			Type: sns.TypeSubscriptionConfirmation, MessageId: "sns-sub", TopicArn: "topic",
+		})},
+		{MessageId: "q-6", ReceiptHandle: "invalid", Body: `not json`},
+		{MessageId: "q-7", ReceiptHandle: "cert-unavailable", Body: envelope(sns.Message{
+			Type: sns.TypeNotification, MessageId: "sns-2", TopicArn: "cert-unavailable", Message: sesEvent("Delivery"),
+		})},
+		{MessageId: "q-8", ReceiptHandle: "disallowed-topic", Body: envelope(sns.Message{
+			Type: sns.TypeNotification, MessageId: "sns-3", TopicArn: "disallowed", Message: sesEvent("Delivery"),
+		})},
+		{MessageId: "q-9", ReceiptHandle: "no-message-id", Body: `{"notificationType":"Delivery","mail":{},"delivery":{}}`},
+	}}
+
+	var subscribed []string
+	c := &eventConsumer{
+		db:    db,
+		queue: queue,
+		verify: func(_ context.Context, msg *sns.Message) error {
+			switch msg.TopicArn {
+			case "forged":
+				return sns.ErrInvalidSignature
+			case "disallowed":
+				return fmt.Errorf("%w: %s", sns.ErrTopicNotAllowed, msg.TopicArn)
+			case "cert-unavailable":
+				return errors.New("failed to fetch signing certificate: connection reset")
+			}
+			return nil
+		},
+		subscribe: func(_ context.Context, msg *sns.Message) (*model.SNSSubscription, error) {
+			subscribed = append(subscribed, msg.TopicArn)
+			return &model.SNSSubscription{TopicArn: msg.TopicArn}, nil
+		},
+		backoff: time.Millisecond,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("종료 신호 후 consumer가 멈추지 않음")
+	}
+
+	wantDeleted := []string{"sns-envelope", "sns-duplicate", "raw-delivery", "forged", "subscription", "invalid", "disallowed-topic", "no-message-id"}
+	if fmt.Sprint(queue.deleted) != fmt.Sprint(wantDeleted) {
+		t.Errorf("삭제된 메시지 = %v, 예상 = %v (일시적 실패 메시지만 재전송 대기)", queue.deleted, wantDeleted)
+	}
+	if len(subscribed) != 1 || subscribed[0] != "topic" {
+		t.Errorf("구독 처리 = %v, 예상 = [topic]", subscribed)
+	}
+
+	var results []model.Result
+	db.Where("request_id = ?", req.ID).Order("id").Find(&results)
+	if len(results) != 2 {
+		t.Fatalf("저장된 결과 수 = %d, 예상 = 2 (SNS 봉투 1건, 원본 메시지 1건)", len(results))
+	}
+	if results[0].SnsMessageId == nil || *results[0].SnsMessageId != "sns-1" {
+		t.Errorf("SNS 봉투 결과의 SnsMessageId = %v, 예상 = sns-1", results[0].SnsMessageId)
+	}
+	if results[1].SnsMessageId == nil || *results[1].SnsMessageId != "q-3" {
+		t.Errorf("원본 메시지 결과의 SnsMessageId = %v, 예상 = SQS MessageId q-3", results[1].SnsMessageId)
+	}
+
+	var got model.Request
+	db.First(&got, req.ID)
+	if got.Status != model.EmailMsgStatusDelivered {
+		t.Errorf("Status = %d, 예상 = %d", got.Status, model.EmailMsgStatusDelivered)
+	}
+}
+
+// errQueue 항상 수신에 실패하는 대기열
+type errQueue struct{ calls int }
+
+func (e *errQueue) Receive(_ context.Context) ([]aws.QueueMessage, error) {
+	e.calls++
+	return nil, errors.New("connection refused")
+}
+
+func (e *errQueue) Delete(_ context.Context, _ string) error { return nil }
+
+// TestEventConsumerReceiveError 수신 실패 시 대기 후 재시도하고 종료 신호에 멈춤
+func TestEventConsumerReceiveError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	queue := &errQueue{}
+	c := &eventConsumer{queue: queue, backoff: 20 * time.Millisecond}
+	c.run(ctx)
+
+	if queue.calls < 2 || queue.calls > 10 {
+		t.Errorf("수신 시도 횟수 = %d, 예상 = 백오프 간격으로 재시도 (2~10회)", queue.calls)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"errors"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRequestNotFound 발송 요청을 찾을 수 없는 경우
+	ErrRequestNotFound = errors.New("request not found")
+	// ErrRequestNotCancellable 이미 발송되었거나 종료되어 취소할 수 없는 요청인 경우
+	ErrRequestNotCancellable = errors.New("request has already been sent or finished")
+)
+
+var (
+	// cancellableStatuses 취소할 수 있는 상태 (대기, 일시 중지, 발송 대기열에 있는 요청)
+	cancellableStatuses = []int{model.EmailMsgStatusCreated, model.EmailMsgStatusPaused, model.EmailMsgStatusProcessing}
+	// pausableStatuses 일시 중지할 수 있는 상태 (대기, 발송 대기열에 있는 요청)
+	pausableStatuses = []int{model.EmailMsgStatusCreated, model.EmailMsgStatusProcessing}
+)
+
+// CancelTopic 토픽의 발송 전 요청을 모두 중지 상태로 전환하고 중지된 수 반환
+func CancelTopic(topicId string) (int64, error) {
+	return cancelTopic(config.GetDB(), topicId)
+}
+
+// cancelTopic 토픽 발송 취소 (의존성 주입 버전)
+func cancelTopic(db *gorm.DB, topicId string) (int64, error) {
+	n, err := setRequestStatus(db.Where("topic_id = ?", topicId), cancellableStatuses, model.EmailMsgStatusStopped)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Cancelled topic %s (stopped=%d)", topicId, n)
+	return n, nil
+}
+
+// PauseTopic 토픽의 발송 전 요청을 일시 중지 상태로 전환하고 일시 중지된 수 반환
+func PauseTopic(topicId string) (int64, error) {
+	return pauseTopic(config.GetDB(), topicId)
+}
+
+// pauseTopic 토픽 발송 일시 중지 (의존성 주입 버전)
+func pauseTopic(db *gorm.DB, topicId string) (int64, error) {
+	n, err := setRequestStatus(db.Where("topic_id = ?", topicId), pausableStatuses, model.EmailMsgStatusPaused)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Paused topic %s (paused=%d)", topicId, n)
+	return n, nil
+}
+
+// ResumeTopic 토픽의 일시 중지된 요청을 대기 상태로 되돌리고 재개된 수 반환
+func ResumeTopic(topicId string) (int64, error) {
+	return resumeTopic(config.GetDB(), topicId)
+}
+
+// resumeTopic 토픽 발송 재개 (의존성 주입 버전)
+func resumeTopic(db *gorm.DB, topicId string) (int64, error) {
+	n, err := setRequestStatus(db.Where("topic_id = ?", topicId), []int{model.EmailMsgStatusPaused}, model.EmailMsgStatusCreated)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Resumed topic %s (resumed=%d)", topicId, n)
+	return n, nil
+}
+
+// CancelRequest 단일 발송 요청을 중지 상태로 전환
+func CancelRequest(requestID uint) error {
+	return cancelRequest(config.GetDB(), requestID)
+}
+
+// cancelRequest 단일 발송 요청 취소 (의존성 주입 버전)
+func cancelRequest(db *gorm.DB, requestID uint) error {
+	n, err := setRequestStatus(db.Where("id = ?", requestID), cancellableStatuses, model.EmailMsgStatusStopped)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("Cancelled request %d", requestID)
+		return nil
+	}
+
+	var req model.Request
+	if err := db.Select("id", "status").First(&req, requestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRequestNotFound
+		}
+		return fmt.Errorf("failed to find request: %w", err)
+	}
+	return fmt.Errorf("%w (status=%d)", ErrRequestNotCancellable, req.Status)
+}
+
+// setRequestStatus 조건에 맞는 요청 중 지정한 상태의 요청을 한 번에 전환하고 전환된 수 반환 (리스 해제)
+// 발송 대기열에 있던 처리 중 요청은 리스가 해제되어 워커가 발송 직전 확인에서 건너뜀
+func setRequestStatus(scope *gorm.DB, from []int, to int) (int64, error) {
+	res := scope.Model(&model.Request{}).
+		Where("status IN ?", from).
+		Updates(map[string]interface{}{
+			"status":       to,
+			"locked_until": nil,
+			"lease_owner":  "",
+		})
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to update request status: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
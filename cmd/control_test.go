@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// createStatusRequest 토픽과 상태가 지정된 테스트용 발송 요청 생성 (처리 중이면 현재 인스턴스가 리스 보유)
+func createStatusRequest(t *testing.T, db *gorm.DB, topicId string, status int) *model.Request {
+	t.Helper()
+	req := createTopicRequest(t, db, "user@example.com", topicId)
+	leaseOwner := ""
+	if status == model.EmailMsgStatusProcessing {
+		leaseOwner = instanceID
+	}
+	if err := db.Model(req).Updates(map[string]interface{}{"status": status, "lease_owner": leaseOwner}).Error; err != nil {
+		t.Fatalf("상태 설정 실패: %v", err)
+	}
+	req.Status, req.LeaseOwner = status, leaseOwner
+	return req
+}
+
+// TestTopicControl 토픽 일시 중지, 재개, 취소 시 상태별 전환 대상과 전환된 수 검증
+func TestTopicControl(t *testing.T) {
+	db := newTestDB(t)
+	created := createStatusRequest(t, db, "promo", model.EmailMsgStatusCreated)
+	queued := createStatusRequest(t, db, "promo", model.EmailMsgStatusProcessing)
+	sent := createStatusRequest(t, db, "promo", model.EmailMsgStatusSent)
+	otherTopic := createStatusRequest(t, db, "billing", model.EmailMsgStatusCreated)
+
+	steps := []struct {
+		name   string                                           // 단계 이름
+		apply  func(db *gorm.DB, topicId string) (int64, error) // 실행할 제어 함수
+		want   int64                                            // 예상 전환 수
+		status map[uint]int                                     // 실행 후 요청별 예상 상태
+	}{
+		{
+			name:  "일시 중지는 대기와 대기열의 요청만 전환",
+			apply: pauseTopic,
+			want:  2,
+			status: map[uint]int{
+				created.ID: model.EmailMsgStatusPaused, queued.ID: model.EmailMsgStatusPaused,
+				sent.ID: model.EmailMsgStatusSent, otherTopic.ID: model.EmailMsgStatusCreated,
+			},
+		},
+		{
+			name:   "재개는 일시 중지된 요청을 대기 상태로",
+			apply:  resumeTopic,
+			want:   2,
+			status: map[uint]int{created.ID: model.EmailMsgStatusCreated, queued.ID: model.EmailMsgStatusCreated},
+		},
+		{
+			name:   "다시 재개하면 전환 대상 없음",
+			apply:  resumeTopic,
+			want:   0,
+			status: map[uint]int{created.ID: model.EmailMsgStatusCreated},
+		},
+		{
+			name:  "취소는 발송 전 요청만 중지",
+			apply: cancelTopic,
+			want:  2,
+			status: map[uint]int{
+				created.ID: model.EmailMsgStatusStopped, queued.ID: model.EmailMsgStatusStopped,
+				sent.ID: model.EmailMsgStatusSent, otherTopic.ID: model.EmailMsgStatusCreated,
+			},
+		},
+	}
+	for _, step := range steps {
+		n, err := step.apply(db, "promo")
+		if err != nil {
+			t.Fatalf("%s: 에러 = %v", step.name, err)
+		}
+		if n != step.want {
+			t.Errorf("%s: 전환 수 = %d, 예상 = %d", step.name, n, step.want)
+		}
+		for id, want := range step.status {
+			if saved := loadRequest(t, db, id); saved.Status != want || saved.LeaseOwner != "" {
+				t.Errorf("%s: RequestID=%d status %d, leaseOwner %q, 예상 status = %d", step.name, id, saved.Status, saved.LeaseOwner, want)
+			}
+		}
+	}
+}
+
+// TestCancelRequest 단일 발송 요청 취소와 취소할 수 없는 요청의 에러 검증
+func TestCancelRequest(t *testing.T) {
+	db := newTestDB(t)
+	paused := createStatusRequest(t, db, "promo", model.EmailMsgStatusPaused)
+	sent := createStatusRequest(t, db, "promo", model.EmailMsgStatusSent)
+
+	tests := []struct {
+		name    string // 테스트 케이스 이름
+		id      uint   // 취소할 요청 ID
+		wantErr error  // 예상 에러
+	}{
+		{"일시 중지된 요청 취소", paused.ID, nil},
+		{"이미 취소된 요청", paused.ID, ErrRequestNotCancellable},
+		{"발송 완료된 요청", sent.ID, ErrRequestNotCancellable},
+		{"없는 요청", 9999, ErrRequestNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cancelRequest(db, tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("cancelRequest() 에러 = %v, 예상 = %v", err, tt.wantErr)
+			}
+		})
+	}
+	if saved := loadRequest(t, db, paused.ID); saved.Status != model.EmailMsgStatusStopped {
+		t.Errorf("취소된 요청 status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusStopped)
+	}
+}
+
+// TestControlDuringSend 발송 중 취소되거나 일시 중지된 요청은 발송 실패 시 재시도 대기 상태로 되살아나지 않음
+func TestControlDuringSend(t *testing.T) {
+	tests := []struct {
+		name       string                                           // 테스트 케이스 이름
+		apply      func(db *gorm.DB, topicId string) (int64, error) // 발송 중 실행할 제어 함수
+		mailerErr  error                                            // 발송 제공자가 반환할 에러
+		wantStatus int                                              // 예상 요청 상태
+	}{
+		{"취소 후 일시적 에러", cancelTopic, mailer.Transient(errors.New("throttled")), model.EmailMsgStatusStopped},
+		{"일시 중지 후 일시적 에러", pauseTopic, mailer.Transient(errors.New("throttled")), model.EmailMsgStatusPaused},
+		{"취소 후 영구 에러", cancelTopic, mailer.Permanent(errors.New("rejected")), model.EmailMsgStatusStopped},
+		{"취소 후 발송 성공은 발송 완료로 기록", cancelTopic, nil, model.EmailMsgStatusSent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			req := createTopicRequest(t, db, "user@example.com", "promo")
+
+			// SES 호출 중에 제어 요청이 처리됨
+			m := &fakeMailer{err: tt.mailerErr, onSend: func() {
+				if _, err := tt.apply(db, "promo"); err != nil {
+					t.Fatalf("제어 함수 에러 = %v", err)
+				}
+			}}
+			_ = sendEmail(context.Background(), req, m, db)
+
+			if saved := loadRequest(t, db, req.ID); saved.Status != tt.wantStatus || saved.NextAttemptAt != nil {
+				t.Errorf("status = %d, nextAttemptAt = %v, 예상 status = %d", saved.Status, saved.NextAttemptAt, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/sesevent"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrMessageIDNotFound SES 이벤트에 messageId가 없는 경우
+	ErrMessageIDNotFound = errors.New("SES message_id not found")
+	// ErrOrphanEvent 요청을 찾지 못해 고아 이벤트로 보관한 경우
+	ErrOrphanEvent = errors.New("SES event does not match any request")
+	// ErrDuplicateEvent 이미 처리한 SNS 메시지가 다시 전달된 경우
+	ErrDuplicateEvent = errors.New("SNS message already processed")
+)
+
+// RecordSESEvent SNS로 전달된 SES 알림/이벤트 게시 메시지를 파싱하여 결과 저장
+// 같은 SNS MessageId가 다시 전달되면 저장하지 않고 ErrDuplicateEvent 반환
+func RecordSESEvent(snsMessageId, raw string) (*model.Result, error) {
+	return recordSESEvent(config.GetDB(), snsMessageId, raw)
+}
+
+// recordSESEvent SES 이벤트 결과 저장 (의존성 주입 버전)
+func recordSESEvent(db *gorm.DB, snsMessageId, raw string) (*model.Result, error) {
+	if dup, err := snsDelivered(db, snsMessageId); err != nil {
+		return nil, err
+	} else if dup {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateEvent, snsMessageId)
+	}
+
+	ev, err := sesevent.Parse([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+	if ev.Mail.MessageId == "" {
+		return nil, ErrMessageIDNotFound
+	}
+	log.Printf("Received %s notification for message %s", ev.Type, ev.Mail.MessageId)
+
+	reqId, err := eventRequestID(db, ev)
+	if err != nil {
+		return nil, err
+	}
+	if reqId == 0 {
+		if err := saveOrphanEvent(db, ev, snsMessageId, raw); err != nil {
+			return nil, duplicateOr(db, snsMessageId, err)
+		}
+		return nil, fmt.Errorf("%w: message_id=%s", ErrOrphanEvent, ev.Mail.MessageId)
+	}
+
+	result := resultFromEvent(ev, raw)
+	result.RequestId = reqId
+	result.SnsMessageId = nullableString(snsMessageId)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(result).Error; err != nil {
+			return fmt.Errorf("failed to save SES result event (requestId=%d): %w", reqId, err)
+		}
+		if err := suppressFromEvent(tx, ev, result, time.Now().UTC()); err != nil {
+			return err
+		}
+		status, ok := deliveryStatus(ev)
+		if !ok {
+			return nil
+		}
+		advanced, err := model.AdvanceDeliveryStatus(tx, result.RequestId, status)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			log.Printf("Request status not advanced by %s event (requestId=%d, stale or duplicate)", ev.Type, reqId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, duplicateOr(db, snsMessageId, err)
+	}
+	return result, nil
+}
+
+// snsDelivered SNS 메시지가 이미 결과나 고아 이벤트로 저장되었는지 여부
+func snsDelivered(db *gorm.DB, snsMessageId string) (bool, error) {
+	if snsMessageId == "" {
+		return false, nil
+	}
+	for _, m := range []interface{}{&model.Result{}, &model.OrphanEvent{}} {
+		var cnt int64
+		if err := db.Unscoped().Model(m).Where("sns_message_id = ?", snsMessageId).Count(&cnt).Error; err != nil {
+			return false, fmt.Errorf("failed to check SNS message %s: %w", snsMessageId, err)
+		}
+		if cnt > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// duplicateOr 저장 실패가 동시에 도착한 중복 전달(유니크 제약 위반) 때문이면 ErrDuplicateEvent, 아니면 원래 에러
+func duplicateOr(db *gorm.DB, snsMessageId string, err error) error {
+	if dup, _ := snsDelivered(db, snsMessageId); dup {
+		return fmt.Errorf("%w: %s", ErrDuplicateEvent, snsMessageId)
+	}
+	return err
+}
+
+// nullableString 빈 문자열은 NULL로 저장 (유니크 인덱스에서 제외)
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// eventRequestID 이벤트의 요청 ID 조회 (X-Request-ID 헤더, 없으면 SES message_id로 조회, 찾지 못하면 0)
+// 헤더가 잘렸거나(headersTruncated) 이 서비스 밖에서 발송된 메일은 X-Request-ID 헤더가 없음
+// 헤더는 메일 작성자가 임의로 넣을 수 있으므로 요청의 message_id가 이벤트와 일치할 때만 사용
+func eventRequestID(db *gorm.DB, ev *sesevent.Event) (uint, error) {
+	var req model.Request
+	if id, err := strconv.Atoi(ev.Mail.Header("X-Request-ID")); err == nil && id > 0 {
+		err := db.Select("id").Where("id = ? AND message_id = ?", id, ev.Mail.MessageId).Take(&req).Error
+		if err == nil {
+			return req.ID, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("failed to find request %d: %w", id, err)
+		}
+		log.Printf("X-Request-ID %d does not match SES message %s, looking up by message_id", id, ev.Mail.MessageId)
+	}
+
+	err := db.Select("id").Where("message_id = ?", ev.Mail.MessageId).Take(&req).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find request by message_id %s: %w", ev.Mail.MessageId, err)
+	}
+	return req.ID, nil
+}
+
+// saveOrphanEvent 요청과 연결하지 못한 이벤트를 버리지 않고 보관
+func saveOrphanEvent(db *gorm.DB, ev *sesevent.Event, snsMessageId, raw string) error {
+	orphan := &model.OrphanEvent{
+		SnsMessageId:     nullableString(snsMessageId),
+		MessageId:        ev.Mail.MessageId,
+		EventType:        ev.Type,
+		HeadersTruncated: ev.Mail.HeadersTruncated,
+		Raw:              raw,
+	}
+	if ts := ev.Timestamp(); !ts.IsZero() {
+		ts = ts.UTC()
+		orphan.EventAt = &ts
+	}
+	if err := db.Create(orphan).Error; err != nil {
+		return fmt.Errorf("failed to save orphan SES event (message_id=%s): %w", ev.Mail.MessageId, err)
+	}
+	log.Printf("Stored orphan %s event for message %s (headersTruncated=%v)", ev.Type, ev.Mail.MessageId, ev.Mail.HeadersTruncated)
+	return nil
+}
+
+// deliveryStatus SES 이벤트에 해당하는 요청 전달 상태 (상태를 바꾸지 않는 이벤트는 false)
+func deliveryStatus(ev *sesevent.Event) (int, bool) {
+	switch ev.Type {
+	case sesevent.TypeDelivery:
+		return model.EmailMsgStatusDelivered, true
+	case sesevent.TypeBounce:
+		if ev.Bounce != nil && ev.Bounce.BounceType == sesevent.BouncePermanent {
+			return model.EmailMsgStatusBounced, true
+		}
+		return model.EmailMsgStatusSoftBounced, true
+	case sesevent.TypeComplaint:
+		return model.EmailMsgStatusComplained, true
+	case sesevent.TypeReject:
+		return model.EmailMsgStatusRejected, true
+	case sesevent.TypeDeliveryDelay:
+		return model.EmailMsgStatusDelayed, true
+	}
+	return 0, false
+}
+
+// resultFromEvent SES 이벤트를 구조화된 결과로 변환
+func resultFromEvent(ev *sesevent.Event, raw string) *model.Result {
+	result := &model.Result{
+		Status:    ev.Type,
+		Raw:       raw,
+		MessageId: ev.Mail.MessageId,
+	}
+	if ts := ev.Timestamp(); !ts.IsZero() {
+		ts = ts.UTC()
+		result.EventAt = &ts
+	}
+
+	switch {
+	case ev.Bounce != nil:
+		result.BounceType = ev.Bounce.BounceType
+		result.BounceSubType = ev.Bounce.BounceSubType
+		result.ReportingMTA = ev.Bounce.ReportingMTA
+	case ev.Complaint != nil:
+		result.ComplaintFeedbackType = ev.Complaint.ComplaintFeedbackType
+		result.ComplaintSubType = ev.Complaint.ComplaintSubType
+		result.UserAgent = ev.Complaint.UserAgent
+	case ev.Delivery != nil:
+		result.SmtpResponse = ev.Delivery.SmtpResponse
+		result.ProcessingTimeMillis = ev.Delivery.ProcessingTimeMillis
+		result.ReportingMTA = ev.Delivery.ReportingMTA
+	case ev.DeliveryDelay != nil:
+		result.DelayType = ev.DeliveryDelay.DelayType
+		result.ReportingMTA = ev.DeliveryDelay.ReportingMTA
+	case ev.Reject != nil:
+		result.Reason = ev.Reject.Reason
+	case ev.Failure != nil:
+		result.Reason = ev.Failure.ErrorMessage
+	case ev.Open != nil:
+		result.UserAgent = ev.Open.UserAgent
+		result.IpAddress = ev.Open.IpAddress
+	case ev.Click != nil:
+		result.Link = ev.Click.Link
+		result.UserAgent = ev.Click.UserAgent
+		result.IpAddress = ev.Click.IpAddress
+	}
+
+	for _, r := range ev.Recipients() {
+		result.Recipients = append(result.Recipients, model.ResultRecipient{
+			Email:          r.EmailAddress,
+			Action:         r.Action,
+			Status:         r.Status,
+			DiagnosticCode: r.DiagnosticCode,
+		})
+	}
+	return result
+}
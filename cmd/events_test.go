@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/sesevent"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// setMessageID 테스트용 요청에 SES 메시지 ID 저장 (이벤트가 X-Request-ID 헤더로 요청을 찾을 수 있도록)
+func setMessageID(t *testing.T, db *gorm.DB, req *model.Request, messageId string) {
+	t.Helper()
+	if err := db.Model(req).Update("message_id", messageId).Error; err != nil {
+		t.Fatalf("Request 갱신 실패: %v", err)
+	}
+}
+
+// TestRecordSESEvent SES 이벤트를 구조화된 컬럼과 수신자별 결과로 저장하는지 테스트
+func TestRecordSESEvent(t *testing.T) {
+	db := newTestDB(t)
+	setMessageID(t, db, createTestRequest(t, db, "a@example.com"), "m-1")
+
+	tests := []struct {
+		name    string                              // 테스트 케이스 이름
+		raw     string                              // SES 메시지 본문
+		check   func(t *testing.T, r *model.Result) // 저장된 결과 검증
+		wantErr error                               // 예상 에러 (nil이면 성공)
+	}{
+		{
+			name: "알림 형식 영구 반송",
+			raw: `{"notificationType":"Bounce","mail":{"timestamp":"2024-01-01T00:00:00Z","messageId":"m-1",
+				"headers":[{"name":"X-Request-ID","value":"1"}]},
+				"bounce":{"bounceType":"Permanent","bounceSubType":"Suppressed","reportingMTA":"dns; mta.example",
+				"timestamp":"2024-01-01T00:00:05Z",
+				"bouncedRecipients":[{"emailAddress":"a@example.com","action":"failed","status":"5.1.1","diagnosticCode":"smtp; 550"}]}}`,
+			check: func(t *testing.T, r *model.Result) {
+				if r.Status != sesevent.TypeBounce || r.BounceType != "Permanent" || r.BounceSubType != "Suppressed" {
+					t.Errorf("반송 정보 = %s/%s/%s", r.Status, r.BounceType, r.BounceSubType)
+				}
+				if r.EventAt == nil || r.EventAt.Second() != 5 {
+					t.Errorf("EventAt = %v, 예상 = 반송 시각", r.EventAt)
+				}
+				if len(r.Recipients) != 1 || r.Recipients[0].Email != "a@example.com" || r.Recipients[0].Status != "5.1.1" {
+					t.Errorf("Recipients = %+v", r.Recipients)
+				}
+			},
+		},
+		{
+			name: "이벤트 게시 형식 전달 지연",
+			raw: `{"eventType":"DeliveryDelay","mail":{"messageId":"m-1","headers":[{"name":"x-request-id","value":"1"}]},
+				"deliveryDelay":{"delayType":"MailboxFull","timestamp":"2024-01-01T00:10:00Z",
+				"delayedRecipients":[{"emailAddress":"a@example.com"},{"emailAddress":"b@example.com"}]}}`,
+			check: func(t *testing.T, r *model.Result) {
+				if r.Status != sesevent.TypeDeliveryDelay || r.DelayType != "MailboxFull" {
+					t.Errorf("지연 정보 = %s/%s", r.Status, r.DelayType)
+				}
+				if len(r.Recipients) != 2 {
+					t.Errorf("Recipients 수 = %d, 예상 = 2", len(r.Recipients))
+				}
+			},
+		},
+		{
+			name:    "messageId 없음",
+			raw:     `{"notificationType":"Delivery","mail":{}}`,
+			wantErr: ErrMessageIDNotFound,
+		},
+		{
+			name:    "X-Request-ID와 message_id 모두 일치하지 않음",
+			raw:     `{"notificationType":"Delivery","mail":{"messageId":"m-3"}}`,
+			wantErr: ErrOrphanEvent,
+		},
+		{
+			name:    "X-Request-ID가 다른 메일의 요청을 가리킴",
+			raw:     `{"notificationType":"Delivery","mail":{"messageId":"m-4","headers":[{"name":"X-Request-ID","value":"1"}]}}`,
+			wantErr: ErrOrphanEvent,
+		},
+		{
+			name: "X-Request-ID가 없는 요청이면 message_id로 조회",
+			raw:  `{"notificationType":"Delivery","mail":{"messageId":"m-1","headers":[{"name":"X-Request-ID","value":"999"}]},"delivery":{}}`,
+			check: func(t *testing.T, r *model.Result) {
+				if r.Status != sesevent.TypeDelivery {
+					t.Errorf("Status = %s, 예상 = %s", r.Status, sesevent.TypeDelivery)
+				}
+			},
+		},
+		{
+			name:    "형식 오류",
+			raw:     `{}`,
+			wantErr: sesevent.ErrInvalidEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := recordSESEvent(db, "sns-"+tt.name, tt.raw)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("recordSESEvent() 에러 = %v, 예상 = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("recordSESEvent() 에러 = %v", err)
+			}
+
+			var saved model.Result
+			if err := db.Preload("Recipients").First(&saved, result.ID).Error; err != nil {
+				t.Fatalf("Result 조회 실패: %v", err)
+			}
+			if saved.RequestId != 1 || saved.Raw != tt.raw {
+				t.Errorf("RequestId = %d, Raw 보존 = %v", saved.RequestId, saved.Raw == tt.raw)
+			}
+			tt.check(t, &saved)
+		})
+	}
+}
+
+// TestRecordSESEventMessageIDFallback X-Request-ID 헤더가 없으면 message_id로 요청을 찾고, 없으면 고아 이벤트로 보관
+func TestRecordSESEventMessageIDFallback(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	if err := db.Model(req).Updates(map[string]interface{}{
+		"message_id": "ses-known",
+		"status":     model.EmailMsgStatusSent,
+	}).Error; err != nil {
+		t.Fatalf("Request 갱신 실패: %v", err)
+	}
+
+	truncated := `{"notificationType":"Delivery","mail":{"messageId":"ses-known","headersTruncated":true},"delivery":{}}`
+	result, err := recordSESEvent(db, "sns-truncated", truncated)
+	if err != nil {
+		t.Fatalf("recordSESEvent() 에러 = %v", err)
+	}
+	if result.RequestId != req.ID {
+		t.Errorf("RequestId = %d, 예상 = %d", result.RequestId, req.ID)
+	}
+	var got model.Request
+	db.First(&got, req.ID)
+	if got.Status != model.EmailMsgStatusDelivered {
+		t.Errorf("Status = %d, 예상 = %d", got.Status, model.EmailMsgStatusDelivered)
+	}
+
+	unknown := `{"eventType":"Bounce","mail":{"messageId":"ses-unknown","headersTruncated":true},
+		"bounce":{"bounceType":"Permanent","timestamp":"2024-01-01T00:00:05Z"}}`
+	if _, err := recordSESEvent(db, "sns-unknown", unknown); !errors.Is(err, ErrOrphanEvent) {
+		t.Fatalf("recordSESEvent() 에러 = %v, 예상 = %v", err, ErrOrphanEvent)
+	}
+	var orphan model.OrphanEvent
+	if err := db.Where("message_id = ?", "ses-unknown").First(&orphan).Error; err != nil {
+		t.Fatalf("고아 이벤트 조회 실패: %v", err)
+	}
+	if orphan.EventType != sesevent.TypeBounce || !orphan.HeadersTruncated || orphan.Raw != unknown || orphan.EventAt == nil {
+		t.Errorf("고아 이벤트 = %+v", orphan)
+	}
+}
+
+// TestRecordSESEventLifecycle 이벤트 우선순위에 따라 요청 상태가 앞으로만 진행되는지 테스트
+func TestRecordSESEventLifecycle(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	if err := db.Model(req).Updates(map[string]interface{}{"message_id": "m-1", "status": model.EmailMsgStatusSent}).Error; err != nil {
+		t.Fatalf("Request 상태 변경 실패: %v", err)
+	}
+
+	event := func(body string) string {
+		return fmt.Sprintf(`{"mail":{"messageId":"m-1","headers":[{"name":"X-Request-ID","value":"%d"}]},%s}`, req.ID, body)
+	}
+	steps := []struct {
+		name string // 단계 이름
+		raw  string // SES 메시지 본문
+		want int    // 처리 후 예상 요청 상태
+	}{
+		{"전달 지연", event(`"eventType":"DeliveryDelay","deliveryDelay":{"delayType":"MailboxFull"}`), model.EmailMsgStatusDelayed},
+		{"일시 반송", event(`"notificationType":"Bounce","bounce":{"bounceType":"Transient"}`), model.EmailMsgStatusSoftBounced},
+		{"전달 완료", event(`"notificationType":"Delivery","delivery":{}`), model.EmailMsgStatusDelivered},
+		{"늦게 도착한 지연 이벤트는 무시", event(`"eventType":"DeliveryDelay","deliveryDelay":{}`), model.EmailMsgStatusDelivered},
+		{"중복 전달 완료는 무시", event(`"notificationType":"Delivery","delivery":{}`), model.EmailMsgStatusDelivered},
+		{"열람 이벤트는 상태 변경 없음", event(`"eventType":"Open","open":{}`), model.EmailMsgStatusDelivered},
+		{"수신 거부 신고", event(`"notificationType":"Complaint","complaint":{}`), model.EmailMsgStatusComplained},
+		{"신고 후 영구 반송은 무시", event(`"notificationType":"Bounce","bounce":{"bounceType":"Permanent"}`), model.EmailMsgStatusComplained},
+	}
+
+	for _, step := range steps {
+		if _, err := recordSESEvent(db, "sns-"+step.name, step.raw); err != nil {
+			t.Fatalf("%s: recordSESEvent() 에러 = %v", step.name, err)
+		}
+		var got model.Request
+		if err := db.First(&got, req.ID).Error; err != nil {
+			t.Fatalf("Request 조회 실패: %v", err)
+		}
+		if got.Status != step.want {
+			t.Errorf("%s: Status = %d, 예상 = %d", step.name, got.Status, step.want)
+		}
+	}
+
+	var results int64
+	db.Model(&model.Result{}).Where("request_id = ?", req.ID).Count(&results)
+	if results != int64(len(steps)) {
+		t.Errorf("저장된 결과 수 = %d, 예상 = %d (무시된 이벤트도 결과는 보존)", results, len(steps))
+	}
+}
+
+// TestAdvanceDeliveryStatusSkipsUnsent 발송 전이거나 실패한 요청은 SES 이벤트로 상태를 바꾸지 않음
+func TestAdvanceDeliveryStatusSkipsUnsent(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+
+	advanced, err := model.AdvanceDeliveryStatus(db, req.ID, model.EmailMsgStatusDelivered)
+	if err != nil {
+		t.Fatalf("AdvanceDeliveryStatus() 에러 = %v", err)
+	}
+	if advanced {
+		t.Error("처리 중 요청의 상태가 변경됨")
+	}
+	if _, err := model.AdvanceDeliveryStatus(db, req.ID, model.EmailMsgStatusFailed); err == nil {
+		t.Error("전달 상태가 아닌 값에 대해 에러가 예상됨")
+	}
+}
+
+// TestRecordSESEventDuplicate 같은 SNS MessageId가 다시 전달되면 저장하지 않음
+func TestRecordSESEventDuplicate(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	setMessageID(t, db, req, "m-1")
+
+	raw := fmt.Sprintf(`{"notificationType":"Delivery","mail":{"messageId":"m-1","headers":[{"name":"X-Request-ID","value":"%d"}]},"delivery":{}}`, req.ID)
+	orphan := `{"notificationType":"Delivery","mail":{"messageId":"m-unknown"},"delivery":{}}`
+
+	tests := []struct {
+		name         string // 테스트 케이스 이름
+		snsMessageId string // SNS MessageId
+		raw          string // SES 메시지 본문
+		wantErr      error  // 예상 에러 (nil이면 저장)
+	}{
+		{"최초 전달", "sns-1", raw, nil},
+		{"같은 SNS 메시지 재전달", "sns-1", raw, ErrDuplicateEvent},
+		{"다른 SNS 메시지", "sns-2", raw, nil},
+		{"고아 이벤트 최초 전달", "sns-3", orphan, ErrOrphanEvent},
+		{"고아 이벤트 재전달", "sns-3", orphan, ErrDuplicateEvent},
+	}
+
+	for _, tt := range tests {
+		_, err := recordSESEvent(db, tt.snsMessageId, tt.raw)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: recordSESEvent() 에러 = %v, 예상 = %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	var results, orphans int64
+	db.Model(&model.Result{}).Count(&results)
+	db.Model(&model.OrphanEvent{}).Count(&orphans)
+	if results != 2 || orphans != 1 {
+		t.Errorf("저장된 결과 = %d, 고아 이벤트 = %d, 예상 = 2, 1", results, orphans)
+	}
+
+	// 동시에 도착한 중복 전달은 유니크 제약으로 막고 ErrDuplicateEvent로 분류
+	dup := &model.Result{RequestId: req.ID, Status: sesevent.TypeDelivery, SnsMessageId: nullableString("sns-1")}
+	if err := db.Create(dup).Error; err == nil {
+		t.Fatal("SNS MessageId 유니크 제약이 적용되지 않음")
+	} else if err := duplicateOr(db, "sns-1", err); !errors.Is(err, ErrDuplicateEvent) {
+		t.Errorf("duplicateOr() = %v, 예상 = %v", err, ErrDuplicateEvent)
+	}
+}
+
+// TestRecordSESEventKeepsSentTime 오래전에 발송된 요청은 늦게 도착한 SES 이벤트로 최근 발송 수에 다시 집계되지 않음
+func TestRecordSESEventKeepsSentTime(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	setMessageID(t, db, req, "m-1")
+	sentAt := time.Now().UTC().Add(-72 * time.Hour)
+	if err := db.Model(req).UpdateColumns(map[string]interface{}{
+		"status":     model.EmailMsgStatusSent,
+		"updated_at": sentAt,
+	}).Error; err != nil {
+		t.Fatalf("Request 갱신 실패: %v", err)
+	}
+
+	event := func(body string) string {
+		return fmt.Sprintf(`{"mail":{"messageId":"m-1","headers":[{"name":"X-Request-ID","value":"%d"}]},%s}`, req.ID, body)
+	}
+	for i, raw := range []string{
+		event(`"notificationType":"Delivery","delivery":{}`),
+		event(`"notificationType":"Complaint","complaint":{}`),
+	} {
+		if _, err := recordSESEvent(db, fmt.Sprintf("sns-%d", i), raw); err != nil {
+			t.Fatalf("recordSESEvent() 에러 = %v", err)
+		}
+	}
+
+	if saved := loadRequest(t, db, req.ID); saved.Status != model.EmailMsgStatusComplained {
+		t.Errorf("Status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusComplained)
+	}
+	cnt, err := model.CountSent(db, time.Now().UTC().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("CountSent() 에러 = %v", err)
+	}
+	if cnt != 0 {
+		t.Errorf("최근 24시간 발송 수 = %d, 예상 = 0 (72시간 전 발송)", cnt)
+	}
+	if cnt, _ := model.CountSent(db, sentAt.Add(-time.Hour)); cnt != 1 {
+		t.Errorf("발송 시각 이후 발송 수 = %d, 예상 = 1", cnt)
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// instanceID 현재 프로세스의 리스 소유자 식별자
+var instanceID = newInstanceID()
+
+// newInstanceID 호스트명, PID, 난수를 조합한 인스턴스 식별자 생성
+func newInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(buf))
+}
+
+// getLeaseDuration 처리 중 요청의 리스 유지 시간
+func getLeaseDuration() time.Duration {
+	return config.GetEnvAsDuration("SEND_LEASE_DURATION", 10*time.Minute)
+}
+
+// releaseRequests 발송하지 못한 요청을 대기 상태로 반환 (현재 인스턴스가 리스를 보유한 경우만)
+func releaseRequests(db *gorm.DB, reqs []*model.Request) (int64, error) {
+	if len(reqs) == 0 {
+		return 0, nil
+	}
+	ids := make([]uint, 0, len(reqs))
+	for _, req := range reqs {
+		ids = append(ids, req.ID)
+	}
+
+	result := db.Model(&model.Request{}).
+		Where("id IN ?", ids).
+		Where("status = ? AND lease_owner = ?", model.EmailMsgStatusProcessing, instanceID).
+		Updates(map[string]interface{}{
+			"status":       model.EmailMsgStatusCreated,
+			"locked_until": nil,
+			"lease_owner":  "",
+		})
+	return result.RowsAffected, result.Error
+}
+
+// leaseScope 요청을 가져간 리스가 그대로 유지된 행만 대상으로 하는 조건 (상태, 소유자, 만료 시각)
+// 리스가 만료되어 복구된 뒤 같은 인스턴스가 다시 가져간 경우도 만료 시각이 달라 구분됨
+func leaseScope(db *gorm.DB, req *model.Request) *gorm.DB {
+	return db.Model(&model.Request{}).
+		Where("id = ? AND status = ? AND lease_owner = ? AND locked_until = ?",
+			req.ID, model.EmailMsgStatusProcessing, instanceID, req.LockedUntil)
+}
+
+// holdsLease 발송 직전 요청의 리스를 아직 보유하고 있는지 확인
+// 대기열에서 기다리는 동안 리스가 만료되어 복구되었거나 다시 할당된 요청은 발송하지 않음 (중복 발송 방지)
+func holdsLease(db *gorm.DB, req *model.Request, now time.Time) (bool, error) {
+	if req.LockedUntil == nil || !req.LockedUntil.After(now) {
+		return false, nil
+	}
+	var count int64
+	if err := leaseScope(db, req).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check request lease: %w", err)
+	}
+	return count > 0, nil
+}
+
+// reapExpiredLeases 리스가 만료된 처리 중 요청을 대기 상태로 복구
+func reapExpiredLeases(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Model(&model.Request{}).
+		Where("status = ?", model.EmailMsgStatusProcessing).
+		Where("locked_until < ? OR locked_until IS NULL", now).
+		Updates(map[string]interface{}{
+			"status":       model.EmailMsgStatusCreated,
+			"locked_until": nil,
+			"lease_owner":  "",
+		})
+	return result.RowsAffected, result.Error
+}
+
+// RunReaper 만료된 리스 복구 작업 실행 (비정상 종료로 처리 중 상태에 남은 요청 복구)
+func RunReaper(ctx context.Context) {
+	db := config.GetDB()
+	interval := config.GetEnvAsDuration("SEND_REAPER_INTERVAL", 1*time.Minute)
+
+	reap := func() {
+		cnt, err := reapExpiredLeases(db.WithContext(ctx), time.Now().UTC())
+		if err != nil {
+			log.Printf("Failed to reap expired leases: %v", err)
+			return
+		}
+		if cnt > 0 {
+			log.Printf("Recovered %d requests with expired leases", cnt)
+		}
+	}
+
+	reap()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reap()
+		}
+	}
+}
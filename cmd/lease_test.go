@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// setLease 테스트용 요청의 상태와 리스 정보 설정
+func setLease(t *testing.T, db *gorm.DB, req *model.Request, status int, lockedUntil *time.Time, owner string) {
+	t.Helper()
+	err := db.Model(&model.Request{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+		"status":       status,
+		"locked_until": lockedUntil,
+		"lease_owner":  owner,
+	}).Error
+	if err != nil {
+		t.Fatalf("리스 설정 실패: %v", err)
+	}
+}
+
+// loadRequest 요청 재조회
+func loadRequest(t *testing.T, db *gorm.DB, id uint) model.Request {
+	t.Helper()
+	var req model.Request
+	if err := db.First(&req, id).Error; err != nil {
+		t.Fatalf("Request 조회 실패: %v", err)
+	}
+	return req
+}
+
+// TestReapExpiredLeases 만료된 리스만 대기 상태로 복구되는지 검증
+func TestReapExpiredLeases(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Now().UTC()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	expired := createTestRequest(t, db, "expired@example.com")
+	setLease(t, db, expired, model.EmailMsgStatusProcessing, &past, "other-instance")
+
+	legacy := createTestRequest(t, db, "legacy@example.com")
+	setLease(t, db, legacy, model.EmailMsgStatusProcessing, nil, "")
+
+	active := createTestRequest(t, db, "active@example.com")
+	setLease(t, db, active, model.EmailMsgStatusProcessing, &future, "other-instance")
+
+	sent := createTestRequest(t, db, "sent@example.com")
+	setLease(t, db, sent, model.EmailMsgStatusSent, &past, "")
+
+	cnt, err := reapExpiredLeases(db, now)
+	if err != nil {
+		t.Fatalf("reapExpiredLeases() 에러 = %v", err)
+	}
+	if cnt != 2 {
+		t.Errorf("복구 건수 = %d, 예상 = 2", cnt)
+	}
+
+	tests := []struct {
+		name       string         // 테스트 케이스 이름
+		req        *model.Request // 검사할 요청
+		wantStatus int            // 예상 상태
+	}{
+		{"만료된 리스는 Created로 복구", expired, model.EmailMsgStatusCreated},
+		{"리스 정보가 없는 처리 중 요청은 Created로 복구", legacy, model.EmailMsgStatusCreated},
+		{"유효한 리스는 유지", active, model.EmailMsgStatusProcessing},
+		{"처리 중이 아닌 요청은 변경하지 않음", sent, model.EmailMsgStatusSent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := loadRequest(t, db, tt.req.ID)
+			if saved.Status != tt.wantStatus {
+				t.Errorf("Status = %d, 예상 = %d", saved.Status, tt.wantStatus)
+			}
+			if tt.wantStatus == model.EmailMsgStatusCreated && (saved.LockedUntil != nil || saved.LeaseOwner != "") {
+				t.Errorf("리스 정보가 초기화되지 않음: locked_until=%v, owner=%q", saved.LockedUntil, saved.LeaseOwner)
+			}
+		})
+	}
+}
+
+// TestDrainQueue 종료 시 채널에 남은 요청이 대기 상태로 반환되는지 검증
+func TestDrainQueue(t *testing.T) {
+	db := newTestDB(t)
+	future := time.Now().UTC().Add(time.Minute)
+
+	own := createTestRequest(t, db, "own@example.com")
+	setLease(t, db, own, model.EmailMsgStatusProcessing, &future, instanceID)
+
+	foreign := createTestRequest(t, db, "foreign@example.com")
+	setLease(t, db, foreign, model.EmailMsgStatusProcessing, &future, "other-instance")
+
+	urgent := createTestRequest(t, db, "urgent@example.com")
+	setLease(t, db, urgent, model.EmailMsgStatusProcessing, &future, instanceID)
+
+	reqChan <- own
+	reqChan <- foreign
+	highChan <- urgent
+
+	if released := drainQueue(db); released != 2 {
+		t.Errorf("반환 건수 = %d, 예상 = 2", released)
+	}
+	if len(reqChan) != 0 || len(highChan) != 0 {
+		t.Errorf("채널에 요청이 남아 있음: normal=%d, high=%d", len(reqChan), len(highChan))
+	}
+	if saved := loadRequest(t, db, own.ID); saved.Status != model.EmailMsgStatusCreated {
+		t.Errorf("자신의 리스 요청 Status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusCreated)
+	}
+	if saved := loadRequest(t, db, foreign.ID); saved.Status != model.EmailMsgStatusProcessing {
+		t.Errorf("다른 인스턴스의 리스 요청 Status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusProcessing)
+	}
+}
+
+// TestHoldsLease 리스가 만료되어 다시 할당되었거나 취소된 요청은 발송 직전 확인에서 제외되는지 검증
+func TestHoldsLease(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	cancelled := createTestRequest(t, db, "cancelled@example.com")
+	setLease(t, db, req, model.EmailMsgStatusCreated, nil, "")
+	now := time.Now().UTC()
+
+	// 처음 가져간 요청이 대기열에 머무는 동안 리스가 만료되어 복구된 뒤 다시 할당됨
+	claimed, err := claimRequests(db, model.EmailPriorityNormal, now, now.Add(time.Minute), 1)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("claimRequests() = %v, %v", claimed, err)
+	}
+	stale := claimed[0]
+	if _, err := reapExpiredLeases(db, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("reapExpiredLeases() 에러 = %v", err)
+	}
+	claimed, err = claimRequests(db, model.EmailPriorityNormal, now, now.Add(3*time.Minute), 1)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("claimRequests() = %v, %v", claimed, err)
+	}
+	current := claimed[0]
+
+	if err := cancelRequest(db, cancelled.ID); err != nil {
+		t.Fatalf("cancelRequest() 에러 = %v", err)
+	}
+
+	tests := []struct {
+		name string         // 테스트 케이스 이름
+		req  *model.Request // 워커가 가진 요청
+		now  time.Time      // 확인 시각
+		want bool           // 리스 보유 여부
+	}{
+		{"현재 리스 보유", current, now, true},
+		{"다시 할당되기 전의 리스", stale, now, false},
+		{"대기열에 있는 동안 만료된 리스", current, now.Add(4 * time.Minute), false},
+		{"취소된 요청", cancelled, now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := holdsLease(db, tt.req, tt.now)
+			if err != nil || ok != tt.want {
+				t.Errorf("holdsLease() = %v, %v, 예상 = %v", ok, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/pkg/aws"
+	"aws-ses-sender-go/pkg/mailer"
+	"aws-ses-sender-go/pkg/smtp"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+var (
+	mailerInstance mailer.Mailer
+	mailerErr      error
+	mailerOnce     sync.Once
+)
+
+// GetMailer 설정된 발송 제공자 반환 (싱글톤)
+func GetMailer() (mailer.Mailer, error) {
+	mailerOnce.Do(func() {
+		mailerInstance, mailerErr = newMailer(context.Background())
+	})
+	return mailerInstance, mailerErr
+}
+
+// GetMailbox 캡처 제공자(file, memory) 사용 시 메일함 반환
+func GetMailbox() (mailer.Mailbox, bool) {
+	m, err := GetMailer()
+	if err != nil {
+		return nil, false
+	}
+	mb, ok := m.(mailer.Mailbox)
+	return mb, ok
+}
+
+// newMailer MAIL_PROVIDER 설정에 따라 발송 제공자 생성
+func newMailer(ctx context.Context) (mailer.Mailer, error) {
+	provider := strings.ToLower(config.GetEnv("MAIL_PROVIDER", "ses"))
+	switch provider {
+	case "ses":
+		sesClient, err := aws.NewSESClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return sesClient, nil
+	case "smtp":
+		smtpClient, err := smtp.NewSMTPClient()
+		if err != nil {
+			return nil, err
+		}
+		return smtpClient, nil
+	case "file":
+		dir := config.GetEnv("SINK_DIR", "./data/mailbox")
+		sink, err := mailer.NewFileSink(config.GetEnv("EMAIL_SENDER", "noreply@localhost"), dir)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("File sink initialized (dir=%s), emails will not be delivered", dir)
+		return sink, nil
+	case "memory":
+		limit := config.GetEnvAsInt("SINK_MAX_MESSAGES", 1000)
+		log.Printf("Memory sink initialized (max=%d), emails will not be delivered", limit)
+		return mailer.NewMemorySink(config.GetEnv("EMAIL_SENDER", "noreply@localhost"), limit), nil
+	default:
+		return nil, fmt.Errorf("unsupported MAIL_PROVIDER: %s", provider)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/tracking"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrPreferenceTokenExpired 환경 설정 페이지 토큰 유효 기간이 지난 경우
+var ErrPreferenceTokenExpired = tracking.ErrTokenExpired
+
+// defaultPreferenceTokenTTL 환경 설정 페이지 토큰 기본 유효 기간
+const defaultPreferenceTokenTTL = 30 * 24 * time.Hour
+
+// CategoryPreference 수신자의 카테고리별 구독 여부
+type CategoryPreference struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Subscribed  bool   `json:"subscribed"`
+}
+
+// PreferenceURL 수신자의 서명된 환경 설정 페이지 URL과 만료 시각
+func PreferenceURL(email string) (string, time.Time) {
+	expiresAt := time.Now().UTC().Add(config.GetEnvAsDuration("PREFERENCE_TOKEN_TTL", defaultPreferenceTokenTTL))
+	return preferenceURL(config.GetEnv("SERVER_HOST", "http://localhost:3000"), email, expiresAt), expiresAt
+}
+
+// preferenceURL 환경 설정 페이지 URL (토큰에 정규화된 수신 주소와 만료 시각 포함)
+func preferenceURL(serverHost, email string, expiresAt time.Time) string {
+	token := getTrackingSigner().Token(tracking.EventPreferences, model.NormalizeEmail(email), expiresAt)
+	return fmt.Sprintf("%s/v1/preferences?token=%s", serverHost, token)
+}
+
+// ParsePreferenceToken 환경 설정 페이지 토큰 검증 후 수신 주소 반환
+func ParsePreferenceToken(token string) (string, error) {
+	return getTrackingSigner().ParseToken(tracking.EventPreferences, token, time.Now())
+}
+
+// ResolveCategory 발송 요청의 구독 카테고리 결정
+// category를 지정하면 등록된 카테고리인지 확인하고, 없으면 topicId가 속한 카테고리 사용
+func ResolveCategory(topicId, category string) (string, error) {
+	return resolveCategory(config.GetDB(), topicId, category)
+}
+
+// resolveCategory 구독 카테고리 결정 (의존성 주입 버전)
+func resolveCategory(db *gorm.DB, topicId, category string) (string, error) {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return model.CategoryForTopic(db, topicId)
+	}
+	c, err := model.FindCategory(db, category)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, category)
+	}
+	return c.Name, nil
+}
+
+// RecipientPreferences 모든 구독 카테고리에 대한 수신자의 구독 여부 (설정이 없으면 구독)
+func RecipientPreferences(email string) ([]CategoryPreference, error) {
+	return recipientPreferences(config.GetDB(), email)
+}
+
+// recipientPreferences 수신자 구독 여부 조회 (의존성 주입 버전)
+func recipientPreferences(db *gorm.DB, email string) ([]CategoryPreference, error) {
+	categories, err := model.ListCategories(db)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := model.Preferences(db, email)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]CategoryPreference, 0, len(categories))
+	for _, c := range categories {
+		subscribed, ok := prefs[c.Name]
+		list = append(list, CategoryPreference{
+			Name:        c.Name,
+			DisplayName: c.Label(),
+			Description: c.Description,
+			Subscribed:  !ok || subscribed,
+		})
+	}
+	return list, nil
+}
+
+// UpdatePreferences 수신자의 카테고리별 구독 여부 저장 (등록되지 않은 카테고리가 있으면 저장하지 않음)
+func UpdatePreferences(email string, prefs map[string]bool) error {
+	return updatePreferences(config.GetDB(), email, prefs)
+}
+
+// updatePreferences 수신자 구독 여부 저장 (의존성 주입 버전)
+func updatePreferences(db *gorm.DB, email string, prefs map[string]bool) error {
+	for name := range prefs {
+		if _, err := model.FindCategory(db, name); err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+	}
+	if err := model.SavePreferences(db, email, prefs); err != nil {
+		return err
+	}
+	log.Printf("Updated subscription preferences for %s: %v", model.NormalizeEmail(email), prefs)
+	return nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"gorm.io/gorm"
+)
+
+// saveTestCategories 테스트용 구독 카테고리 등록 (marketing: promo 토픽, billing)
+func saveTestCategories(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	marketing := &model.SubscriptionCategory{Name: "marketing", DisplayName: "Marketing", Description: "Offers and news"}
+	marketing.SetTopics([]string{"promo"})
+	for _, c := range []*model.SubscriptionCategory{marketing, {Name: "billing"}} {
+		if err := model.SaveCategory(db, c); err != nil {
+			t.Fatalf("SaveCategory() 에러 = %v", err)
+		}
+	}
+}
+
+// TestResolveCategory 지정한 카테고리 또는 토픽이 속한 카테고리로 분류
+func TestResolveCategory(t *testing.T) {
+	db := newTestDB(t)
+	saveTestCategories(t, db)
+
+	tests := []struct {
+		name     string // 테스트 케이스 이름
+		topicId  string // 요청 토픽
+		category string // 요청에 지정한 카테고리
+		want     string // 예상 카테고리
+		wantErr  error  // 예상 에러
+	}{
+		{"토픽이 속한 카테고리", "promo", "", "marketing", nil},
+		{"카테고리가 없는 토픽", "otp", "", "", nil},
+		{"지정한 카테고리 우선", "promo", " billing ", "billing", nil},
+		{"등록되지 않은 카테고리", "promo", "unknown", "", model.ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveCategory(db, tt.topicId, tt.category)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("resolveCategory() = %q, %v, 예상 = %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestUpdatePreferences 수신자 구독 여부 저장과 카테고리별 조회 (설정이 없으면 구독)
+func TestUpdatePreferences(t *testing.T) {
+	db := newTestDB(t)
+	saveTestCategories(t, db)
+
+	if err := updatePreferences(db, "user@example.com", map[string]bool{"marketing": false, "unknown": false}); !errors.Is(err, model.ErrCategoryNotFound) {
+		t.Fatalf("updatePreferences() 에러 = %v, 예상 = ErrCategoryNotFound", err)
+	}
+	if err := updatePreferences(db, "User@example.com", map[string]bool{"marketing": false}); err != nil {
+		t.Fatalf("updatePreferences() 에러 = %v", err)
+	}
+
+	prefs, err := recipientPreferences(db, "user@example.com")
+	if err != nil {
+		t.Fatalf("recipientPreferences() 에러 = %v", err)
+	}
+	want := []CategoryPreference{
+		{Name: "billing", DisplayName: "billing", Subscribed: true},
+		{Name: "marketing", DisplayName: "Marketing", Description: "Offers and news", Subscribed: false},
+	}
+	if len(prefs) != len(want) {
+		t.Fatalf("recipientPreferences() = %+v, 예상 = %+v", prefs, want)
+	}
+	for i := range want {
+		if prefs[i] != want[i] {
+			t.Errorf("recipientPreferences()[%d] = %+v, 예상 = %+v", i, prefs[i], want[i])
+		}
+	}
+}
+
+// TestPreferenceURL 환경 설정 페이지 URL의 토큰으로 수신 주소 확인
+func TestPreferenceURL(t *testing.T) {
+	link := preferenceURL("https://mail.example.com", " User@Example.com", time.Now().Add(time.Hour))
+	if !strings.HasPrefix(link, "https://mail.example.com/v1/preferences?token=") {
+		t.Fatalf("preferenceURL() = %s", link)
+	}
+	u, _ := url.Parse(link)
+	email, err := ParsePreferenceToken(u.Query().Get("token"))
+	if err != nil || email != "user@example.com" {
+		t.Errorf("ParsePreferenceToken() = %q, %v, 예상 = user@example.com", email, err)
+	}
+
+	expired := preferenceURL("https://mail.example.com", "user@example.com", time.Now().Add(-time.Minute))
+	u, _ = url.Parse(expired)
+	if _, err := ParsePreferenceToken(u.Query().Get("token")); !errors.Is(err, ErrPreferenceTokenExpired) {
+		t.Errorf("만료된 토큰 에러 = %v, 예상 = %v", err, ErrPreferenceTokenExpired)
+	}
+}
+
+// TestDropOptedOutCategory 카테고리 구독을 해지한 수신자의 해당 카테고리 요청만 발송 대기열에서 제외
+func TestDropOptedOutCategory(t *testing.T) {
+	db := newTestDB(t)
+	if err := model.SavePreferences(db, "user@example.com", map[string]bool{"marketing": false, "billing": true}); err != nil {
+		t.Fatalf("SavePreferences() 에러 = %v", err)
+	}
+
+	marketing := createTestRequest(t, db, "User@example.com")
+	billing := createTestRequest(t, db, "user@example.com")
+	other := createTestRequest(t, db, "other@example.com")
+	for req, category := range map[*model.Request]string{marketing: "marketing", billing: "billing", other: "marketing"} {
+		db.Model(req).Update("category", category)
+		req.Category = category
+	}
+
+	kept, err := dropUnsubscribed(db, []*model.Request{marketing, billing, other})
+	if err != nil {
+		t.Fatalf("dropUnsubscribed() 에러 = %v", err)
+	}
+	if len(kept) != 2 || kept[0].ID != billing.ID || kept[1].ID != other.ID {
+		t.Fatalf("남은 요청 수 = %d, 예상 = [%d %d]", len(kept), billing.ID, other.ID)
+	}
+	saved := loadRequest(t, db, marketing.ID)
+	if saved.Status != model.EmailMsgStatusUnsubscribed || saved.Error != "unsubscribed: category marketing" {
+		t.Errorf("status %d, error %q, 예상 = %d, unsubscribed: category marketing", saved.Status, saved.Error, model.EmailMsgStatusUnsubscribed)
+	}
+}
+
+// TestDropOptedOutCategoryByTopic 카테고리 없이 생성된 요청도 가져갈 때 토픽이 속한 카테고리로 구독 해지 확인
+func TestDropOptedOutCategoryByTopic(t *testing.T) {
+	db := newTestDB(t)
+	promo := createTopicRequest(t, db, "user@example.com", "promo")
+	news := createTopicRequest(t, db, "user@example.com", "news")
+
+	// 요청 생성 이후 토픽이 카테고리에 추가되고 수신자가 구독을 해지
+	saveTestCategories(t, db)
+	if err := model.SavePreferences(db, "user@example.com", map[string]bool{"marketing": false}); err != nil {
+		t.Fatalf("SavePreferences() 에러 = %v", err)
+	}
+
+	kept, err := dropUnsubscribed(db, []*model.Request{promo, news})
+	if err != nil {
+		t.Fatalf("dropUnsubscribed() 에러 = %v", err)
+	}
+	if len(kept) != 1 || kept[0].ID != news.ID {
+		t.Fatalf("남은 요청 수 = %d, 예상 = [%d]", len(kept), news.ID)
+	}
+	if saved := loadRequest(t, db, promo.ID); saved.Status != model.EmailMsgStatusUnsubscribed || saved.Error != "unsubscribed: category marketing" {
+		t.Errorf("status %d, error %q, 예상 = %d, unsubscribed: category marketing", saved.Status, saved.Error, model.EmailMsgStatusUnsubscribed)
+	}
+}
+
+// TestSendNowOptedOutCategory 즉시 발송도 구독 해지한 카테고리는 발송하지 않음
+func TestSendNowOptedOutCategory(t *testing.T) {
+	db := newTestDB(t)
+	content, req := newSendNowRequest()
+	req.Category = "marketing"
+	if err := model.SavePreferences(db, req.To, map[string]bool{"marketing": false}); err != nil {
+		t.Fatalf("SavePreferences() 에러 = %v", err)
+	}
+
+	m := &fakeMailer{}
+	result, err := sendNow(context.Background(), db, m, newRateController(100, nil, 0.9, time.Minute), semaphore.NewWeighted(1), content, req)
+	if !errors.Is(err, ErrRecipientUnsubscribed) {
+		t.Fatalf("sendNow() 에러 = %v, 예상 = %v", err, ErrRecipientUnsubscribed)
+	}
+	if result.Status != "unsubscribed" || len(m.sent) != 0 {
+		t.Errorf("결과 = %+v, 발송 수 = %d, 예상 = unsubscribed, 0", result, len(m.sent))
+	}
+}
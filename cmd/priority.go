@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"context"
+)
+
+// laneSelector 우선순위 대기열 간 가중치 기반 공정 선택
+// 우선 대기열에서 weight건을 꺼낼 때마다 일반 대기열에서 1건을 꺼내며, 한쪽이 비어 있으면 다른 쪽을 바로 사용
+type laneSelector struct {
+	high   <-chan *model.Request
+	normal <-chan *model.Request
+	weight int // 일반 요청 1건당 우선 요청 처리 수
+	credit int // 마지막 일반 요청 이후 연속 처리한 우선 요청 수
+}
+
+// newLaneSelector 대기열 선택기 생성
+func newLaneSelector(high, normal <-chan *model.Request, weight int) *laneSelector {
+	if weight < 1 {
+		weight = 1
+	}
+	return &laneSelector{high: high, normal: normal, weight: weight}
+}
+
+// next 다음 발송 요청 선택 (요청이 없으면 도착할 때까지 대기)
+func (s *laneSelector) next(ctx context.Context) (*model.Request, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	first, second := s.high, s.normal
+	if s.credit >= s.weight {
+		first, second = s.normal, s.high
+	}
+	for _, ch := range []<-chan *model.Request{first, second} {
+		select {
+		case req := <-ch:
+			s.record(ch)
+			return req, nil
+		default:
+		}
+	}
+
+	select {
+	case req := <-s.high:
+		s.record(s.high)
+		return req, nil
+	case req := <-s.normal:
+		s.record(s.normal)
+		return req, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// record 선택한 대기열에 따라 가중치 상태 갱신
+func (s *laneSelector) record(ch <-chan *model.Request) {
+	if ch == s.high {
+		s.credit++
+		return
+	}
+	s.credit = 0
+}
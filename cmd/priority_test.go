@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestLaneSelector 가중치 기반 대기열 선택 순서 검증
+func TestLaneSelector(t *testing.T) {
+	tests := []struct {
+		name   string // 테스트 케이스 이름
+		high   int    // 우선 대기열 요청 수
+		normal int    // 일반 대기열 요청 수
+		weight int    // 가중치
+		want   string // 예상 선택 순서 (h: 우선, n: 일반)
+	}{
+		{"가중치만큼 우선 요청 후 일반 요청 1건", 5, 3, 2, "hhnhhnhn"},
+		{"우선 대기열이 비면 일반 대기열 사용", 1, 3, 4, "hnnn"},
+		{"일반 대기열이 비면 우선 대기열 연속 사용", 4, 0, 1, "hhhh"},
+		{"가중치가 0 이하이면 1로 보정", 2, 2, 0, "hnhn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			high := make(chan *model.Request, tt.high)
+			normal := make(chan *model.Request, tt.normal)
+			for i := 0; i < tt.high; i++ {
+				high <- &model.Request{Priority: model.EmailPriorityHigh}
+			}
+			for i := 0; i < tt.normal; i++ {
+				normal <- &model.Request{Priority: model.EmailPriorityNormal}
+			}
+
+			s := newLaneSelector(high, normal, tt.weight)
+			got := ""
+			for i := 0; i < tt.high+tt.normal; i++ {
+				req, err := s.next(context.Background())
+				if err != nil {
+					t.Fatalf("next() 에러 = %v", err)
+				}
+				if req.Priority == model.EmailPriorityHigh {
+					got += "h"
+				} else {
+					got += "n"
+				}
+			}
+			if got != tt.want {
+				t.Errorf("선택 순서 = %s, 예상 = %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLaneSelectorWaits 요청이 없으면 도착할 때까지 대기하고 종료 신호에 반환되는지 검증
+func TestLaneSelectorWaits(t *testing.T) {
+	high := make(chan *model.Request, 1)
+	normal := make(chan *model.Request, 1)
+	s := newLaneSelector(high, normal, 4)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		high <- &model.Request{Priority: model.EmailPriorityHigh}
+	}()
+	req, err := s.next(context.Background())
+	if err != nil || req.Priority != model.EmailPriorityHigh {
+		t.Fatalf("next() = %v, %v, 우선 요청 예상", req, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.next(ctx); err == nil {
+		t.Error("종료 신호 후 에러를 예상했지만 nil이 반환됨")
+	}
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateController 계정 발송 한도 기반 발송 속도 제어
+type rateController struct {
+	limiter      *rate.Limiter
+	provider     mailer.QuotaProvider // 계정 한도 조회 제공자 (nil이면 고정 속도 사용)
+	margin       float64              // 계정 한도 대비 사용 비율
+	minRate      float64              // 속도 제한 시 최저 발송 속도
+	recoverDelay time.Duration        // 마지막 속도 제한 이후 회복 시작까지 대기 시간
+
+	mu           sync.Mutex
+	ceiling      float64       // 계정 한도 기준 최대 발송 속도
+	current      float64       // 현재 발송 속도
+	dailyLimit   float64       // 24시간 발송 허용량 (0이면 무제한)
+	sentToday    float64       // 최근 24시간 발송 수 (조회 이후 발송분 포함)
+	lastThrottle time.Time     // 마지막 속도 제한 시각
+	resume       chan struct{} // 일시 중지 중이면 재개 시 닫히는 채널
+}
+
+var (
+	rateControllerInstance *rateController
+	rateControllerOnce     sync.Once
+)
+
+// getRateController 발송 속도 제어기 반환 (싱글톤, 생성 시 계정 한도 1회 조회)
+func getRateController() *rateController {
+	rateControllerOnce.Do(func() {
+		emailRate, err := strconv.Atoi(config.GetEnv("EMAIL_RATE", "14"))
+		if err != nil {
+			log.Fatalf("Invalid EMAIL_RATE: %v", err)
+		}
+
+		var provider mailer.QuotaProvider
+		if m, err := GetMailer(); err == nil {
+			provider, _ = m.(mailer.QuotaProvider)
+		}
+
+		rc := newRateController(float64(emailRate), provider,
+			config.GetEnvAsFloat("SEND_RATE_SAFETY_MARGIN", 0.9),
+			config.GetEnvAsDuration("SEND_RATE_RECOVER_INTERVAL", 30*time.Second))
+		rc.refresh(context.Background())
+		rateControllerInstance = rc
+	})
+	return rateControllerInstance
+}
+
+// newRateController 고정 속도로 초기화된 발송 속도 제어기 생성
+func newRateController(staticRate float64, provider mailer.QuotaProvider, margin float64, recoverDelay time.Duration) *rateController {
+	if staticRate <= 0 {
+		staticRate = 1
+	}
+	if margin <= 0 || margin > 1 {
+		margin = 0.9
+	}
+	return &rateController{
+		limiter:      rate.NewLimiter(rate.Limit(staticRate), burstFor(staticRate)),
+		provider:     provider,
+		margin:       margin,
+		minRate:      math.Min(1, staticRate),
+		recoverDelay: recoverDelay,
+		ceiling:      staticRate,
+		current:      staticRate,
+	}
+}
+
+// run 계정 한도 주기적 갱신 및 속도 회복 실행
+func (rc *rateController) run(ctx context.Context) {
+	refreshTicker := time.NewTicker(config.GetEnvAsDuration("SEND_QUOTA_REFRESH_INTERVAL", 5*time.Minute))
+	defer refreshTicker.Stop()
+	recoverTicker := time.NewTicker(max(rc.recoverDelay, time.Second))
+	defer recoverTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-refreshTicker.C:
+			rc.refresh(ctx)
+		case now := <-recoverTicker.C:
+			rc.recover(now)
+		}
+	}
+}
+
+// refresh 제공자에서 계정 한도를 조회하여 반영 (실패 시 기존 설정 유지)
+func (rc *rateController) refresh(ctx context.Context) {
+	if rc.provider == nil {
+		return
+	}
+	quotaCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	quota, err := rc.provider.Quota(quotaCtx)
+	if err != nil {
+		log.Printf("Failed to refresh send quota, keeping current rate: %v", err)
+		return
+	}
+	rc.applyQuota(quota)
+}
+
+// applyQuota 계정 한도에 안전 비율을 적용하여 발송 속도와 일일 허용량 갱신
+func (rc *rateController) applyQuota(q *mailer.Quota) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	ceiling := rc.ceiling
+	if q.MaxSendRate > 0 {
+		ceiling = math.Max(q.MaxSendRate*rc.margin, rc.minRate)
+	}
+	// 속도 제한으로 낮춘 상태가 아니면 새 한도를 바로 따라감
+	if rc.current >= rc.ceiling || rc.current > ceiling {
+		rc.current = ceiling
+	}
+	rc.ceiling = ceiling
+	rc.applyRateLocked()
+
+	rc.dailyLimit = 0
+	if q.Max24HourSend > 0 {
+		rc.dailyLimit = q.Max24HourSend * rc.margin
+	}
+	rc.sentToday = q.SentLast24Hours
+	rc.updatePauseLocked()
+
+	log.Printf("Send quota refreshed (max_rate=%.2f/sec, rate=%.2f/sec, sent_24h=%.0f/%.0f)",
+		q.MaxSendRate, rc.current, q.SentLast24Hours, q.Max24HourSend)
+}
+
+// observe 발송 결과 반영 (성공 시 발송 수 기록, 속도 제한 에러 시 속도 감소)
+func (rc *rateController) observe(err error) {
+	switch {
+	case err == nil:
+		rc.onSent()
+	case mailer.IsThrottled(err):
+		rc.onThrottle(time.Now())
+	}
+}
+
+// onSent 발송 성공 기록 (일일 허용량 소진 시 일시 중지)
+func (rc *rateController) onSent() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.sentToday++
+	rc.updatePauseLocked()
+}
+
+// onThrottle 속도 제한 응답 수신 시 발송 속도를 절반으로 감소
+func (rc *rateController) onThrottle(now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.lastThrottle = now
+	if rc.current <= rc.minRate {
+		return
+	}
+	rc.current = math.Max(rc.current/2, rc.minRate)
+	rc.applyRateLocked()
+	log.Printf("Send rate throttled, reducing rate to %.2f/sec", rc.current)
+}
+
+// recover 속도 제한 이후 일정 시간이 지나면 최대 속도의 10%씩 회복
+func (rc *rateController) recover(now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.current >= rc.ceiling || now.Sub(rc.lastThrottle) < rc.recoverDelay {
+		return
+	}
+	rc.current = math.Min(rc.current+rc.ceiling/10, rc.ceiling)
+	rc.applyRateLocked()
+	log.Printf("Send rate recovering to %.2f/sec", rc.current)
+}
+
+// paused 일시 중지 중이면 재개 시 닫히는 채널 반환 (발송 가능하면 nil)
+func (rc *rateController) paused() <-chan struct{} {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.resume
+}
+
+// limit 현재 발송 속도 (초당)
+func (rc *rateController) limit() float64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.current
+}
+
+// applyRateLocked 현재 발송 속도를 리미터에 반영 (mu 보유 상태에서 호출)
+func (rc *rateController) applyRateLocked() {
+	rc.limiter.SetLimit(rate.Limit(rc.current))
+	rc.limiter.SetBurst(burstFor(rc.current))
+}
+
+// updatePauseLocked 일일 허용량 소진 여부에 따라 일시 중지 상태 전환 (mu 보유 상태에서 호출)
+func (rc *rateController) updatePauseLocked() {
+	exhausted := rc.dailyLimit > 0 && rc.sentToday >= rc.dailyLimit
+	switch {
+	case exhausted && rc.resume == nil:
+		rc.resume = make(chan struct{})
+		log.Printf("Daily send quota nearly exhausted (sent=%.0f, limit=%.0f), pausing sender", rc.sentToday, rc.dailyLimit)
+	case !exhausted && rc.resume != nil:
+		close(rc.resume)
+		rc.resume = nil
+		log.Println("Daily send quota available again, resuming sender")
+	}
+}
+
+// burstFor 발송 속도에 맞는 리미터 버스트 크기
+func burstFor(r float64) int {
+	return max(1, int(r))
+}
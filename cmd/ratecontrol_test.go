@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// fakeQuotaProvider 테스트용 계정 한도 제공자
+type fakeQuotaProvider struct {
+	quota *mailer.Quota
+	err   error
+}
+
+func (f *fakeQuotaProvider) Quota(_ context.Context) (*mailer.Quota, error) {
+	return f.quota, f.err
+}
+
+// TestRateControllerRefresh 계정 한도 기반 발송 속도 설정 검증
+func TestRateControllerRefresh(t *testing.T) {
+	tests := []struct {
+		name     string        // 테스트 케이스 이름
+		quota    *mailer.Quota // 제공자가 반환할 한도
+		err      error         // 제공자가 반환할 에러
+		wantRate float64       // 예상 발송 속도
+	}{
+		{"안전 비율을 적용한 속도로 설정", &mailer.Quota{MaxSendRate: 100}, nil, 90},
+		{"최대 속도가 없으면 고정 속도 유지", &mailer.Quota{}, nil, 14},
+		{"조회 실패 시 고정 속도 유지", nil, errors.New("boom"), 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := newRateController(14, &fakeQuotaProvider{quota: tt.quota, err: tt.err}, 0.9, time.Minute)
+			rc.refresh(context.Background())
+
+			if got := rc.limit(); got != tt.wantRate {
+				t.Errorf("limit() = %v, 예상 = %v", got, tt.wantRate)
+			}
+			if got := rc.limiter.Limit(); got != rate.Limit(tt.wantRate) {
+				t.Errorf("limiter.Limit() = %v, 예상 = %v", got, tt.wantRate)
+			}
+		})
+	}
+}
+
+// TestRateControllerThrottle 속도 제한 시 감소 및 점진적 회복 검증
+func TestRateControllerThrottle(t *testing.T) {
+	rc := newRateController(14, nil, 0.9, time.Minute)
+	rc.applyQuota(&mailer.Quota{MaxSendRate: 20})
+	start := time.Now()
+
+	rc.onThrottle(start)
+	if got := rc.limit(); got != 9 {
+		t.Fatalf("속도 제한 후 limit() = %v, 예상 = 9", got)
+	}
+
+	// 회복 대기 시간 이전에는 유지
+	rc.recover(start.Add(30 * time.Second))
+	if got := rc.limit(); got != 9 {
+		t.Errorf("회복 대기 중 limit() = %v, 예상 = 9", got)
+	}
+
+	// 최대 속도의 10%씩 회복하며 최대 속도를 넘지 않음
+	rc.recover(start.Add(time.Minute))
+	if got := rc.limit(); got != 10.8 {
+		t.Errorf("회복 후 limit() = %v, 예상 = 10.8", got)
+	}
+	for i := 0; i < 10; i++ {
+		rc.recover(start.Add(2 * time.Minute))
+	}
+	if got := rc.limit(); got != 18 {
+		t.Errorf("완전 회복 후 limit() = %v, 예상 = 18", got)
+	}
+
+	// 속도 제한이 반복되어도 최저 속도 아래로 내려가지 않음
+	for i := 0; i < 10; i++ {
+		rc.onThrottle(start)
+	}
+	if got := rc.limit(); got != rc.minRate {
+		t.Errorf("반복 속도 제한 후 limit() = %v, 예상 = %v", got, rc.minRate)
+	}
+
+	// 속도 제한 상태에서 한도가 갱신되어도 낮춘 속도 유지
+	rc.applyQuota(&mailer.Quota{MaxSendRate: 20})
+	if got := rc.limit(); got != rc.minRate {
+		t.Errorf("한도 갱신 후 limit() = %v, 예상 = %v", got, rc.minRate)
+	}
+}
+
+// TestRateControllerDailyQuota 일일 한도 소진 시 일시 중지 및 재개 검증
+func TestRateControllerDailyQuota(t *testing.T) {
+	provider := &fakeQuotaProvider{quota: &mailer.Quota{MaxSendRate: 10, Max24HourSend: 100, SentLast24Hours: 88}}
+	rc := newRateController(14, provider, 0.9, time.Minute)
+	rc.refresh(context.Background())
+
+	if rc.paused() != nil {
+		t.Fatal("허용량이 남아 있는데 일시 중지됨")
+	}
+
+	// 발송 기록으로 허용량(100 * 0.9)에 도달하면 일시 중지
+	rc.onSent()
+	rc.onSent()
+	resume := rc.paused()
+	if resume == nil {
+		t.Fatal("허용량 소진 후 일시 중지되지 않음")
+	}
+
+	// 24시간 발송 수가 줄어들면 재개
+	provider.quota = &mailer.Quota{MaxSendRate: 10, Max24HourSend: 100, SentLast24Hours: 40}
+	rc.refresh(context.Background())
+	select {
+	case <-resume:
+	default:
+		t.Fatal("허용량 회복 후 재개 채널이 닫히지 않음")
+	}
+	if rc.paused() != nil {
+		t.Error("허용량 회복 후에도 일시 중지 상태")
+	}
+
+	// 무제한 한도는 일시 중지하지 않음
+	provider.quota = &mailer.Quota{MaxSendRate: 10, Max24HourSend: -1, SentLast24Hours: 1000000}
+	rc.refresh(context.Background())
+	if rc.paused() != nil {
+		t.Error("무제한 한도에서 일시 중지됨")
+	}
+}
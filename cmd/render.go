@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// ValidateContent 제목과 본문의 템플릿 문법 검증
+func ValidateContent(subject, html, text string) error {
+	if _, err := texttemplate.New("subject").Parse(subject); err != nil {
+		return fmt.Errorf("invalid subject template: %w", err)
+	}
+	if _, err := htmltemplate.New("content").Parse(html); err != nil {
+		return fmt.Errorf("invalid content template: %w", err)
+	}
+	if _, err := texttemplate.New("text").Parse(text); err != nil {
+		return fmt.Errorf("invalid text template: %w", err)
+	}
+	return nil
+}
+
+// renderedContent 수신자 변수로 렌더링된 컨텐츠
+type renderedContent struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// renderContent 수신자 변수로 제목과 본문 렌더링
+// 엄격 모드는 변수가 없으면 에러, 일반 모드는 기본값(없으면 빈 문자열) 사용
+func renderContent(content *model.Content, reqVars string) (*renderedContent, error) {
+	vars, err := mergeVars(content.DefaultVars, reqVars)
+	if err != nil {
+		return nil, err
+	}
+	option := "missingkey=zero"
+	if content.Strict {
+		option = "missingkey=error"
+	}
+
+	subject, err := renderText("subject", content.Subject, vars, option)
+	if err != nil {
+		return nil, err
+	}
+	text, err := renderText("text", content.Text, vars, option)
+	if err != nil {
+		return nil, err
+	}
+
+	html := content.Content
+	if strings.Contains(html, "{{") {
+		tmpl, err := htmltemplate.New("content").Option(option).Parse(html)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content template: %w", err)
+		}
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return nil, fmt.Errorf("failed to render content: %w", err)
+		}
+		html = buf.String()
+	}
+
+	return &renderedContent{Subject: subject, HTML: html, Text: text}, nil
+}
+
+// renderText 텍스트 템플릿 렌더링 (템플릿 문법이 없으면 원문 그대로 사용)
+func renderText(name, src string, vars map[string]string, option string) (string, error) {
+	if !strings.Contains(src, "{{") {
+		return src, nil
+	}
+	tmpl, err := texttemplate.New(name).Option(option).Parse(src)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// mergeVars 컨텐츠 기본 변수에 수신자 변수를 덮어써서 병합
+func mergeVars(defaults, overrides string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, raw := range []string{defaults, overrides} {
+		if raw == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(raw), &vars); err != nil {
+			return nil, fmt.Errorf("invalid template vars: %w", err)
+		}
+	}
+	return vars, nil
+}
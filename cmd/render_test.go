@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"testing"
+)
+
+// TestRenderContent 수신자 변수 기반 제목/본문 렌더링 검증
+func TestRenderContent(t *testing.T) {
+	tests := []struct {
+		name        string        // 테스트 케이스 이름
+		content     model.Content // 컨텐츠 (템플릿, 기본 변수, 엄격 모드)
+		vars        string        // 수신자 변수 (JSON)
+		wantSubject string        // 예상 제목
+		wantHTML    string        // 예상 본문
+		wantText    string        // 예상 텍스트 본문
+		wantErr     bool          // 에러 발생 여부
+	}{
+		{
+			name:        "템플릿 문법이 없으면 원문 그대로 사용",
+			content:     model.Content{Subject: "공지", Content: "<p>안내</p>"},
+			vars:        `{"name":"홍길동"}`,
+			wantSubject: "공지",
+			wantHTML:    "<p>안내</p>",
+		},
+		{
+			name:        "수신자 변수로 렌더링",
+			content:     model.Content{Subject: "{{.name}}님 안녕하세요", Content: `<a href="{{.link}}">{{.name}}</a>`},
+			vars:        `{"name":"홍길동","link":"https://example.com/a?id=1"}`,
+			wantSubject: "홍길동님 안녕하세요",
+			wantHTML:    `<a href="https://example.com/a?id=1">홍길동</a>`,
+		},
+		{
+			name:        "수신자 변수가 기본 변수보다 우선",
+			content:     model.Content{Subject: "{{.greeting}} {{.name}}", Content: "<p>{{.name}}</p>", DefaultVars: `{"greeting":"안녕하세요","name":"고객"}`},
+			vars:        `{"name":"홍길동"}`,
+			wantSubject: "안녕하세요 홍길동",
+			wantHTML:    "<p>홍길동</p>",
+		},
+		{
+			name:        "일반 모드는 없는 변수를 빈 문자열로 렌더링",
+			content:     model.Content{Subject: "{{.name}}님", Content: "<p>{{.missing}}</p>"},
+			wantSubject: "님",
+			wantHTML:    "<p></p>",
+		},
+		{
+			name:    "엄격 모드는 없는 변수가 있으면 에러",
+			content: model.Content{Subject: "{{.name}}님", Content: "<p>{{.missing}}</p>", Strict: true},
+			vars:    `{"name":"홍길동"}`,
+			wantErr: true,
+		},
+		{
+			name:        "텍스트 본문은 이스케이프 없이 렌더링",
+			content:     model.Content{Subject: "안내", Content: "<p>{{.name}}</p>", Text: "{{.name}}님 <안내>"},
+			vars:        `{"name":"A&B"}`,
+			wantSubject: "안내",
+			wantHTML:    "<p>A&amp;B</p>",
+			wantText:    "A&B님 <안내>",
+		},
+		{
+			name:        "본문 변수는 HTML 이스케이프",
+			content:     model.Content{Subject: "{{.name}}", Content: "<p>{{.name}}</p>"},
+			vars:        `{"name":"<b>A&B</b>"}`,
+			wantSubject: "<b>A&B</b>",
+			wantHTML:    "<p>&lt;b&gt;A&amp;B&lt;/b&gt;</p>",
+		},
+		{
+			name:    "잘못된 변수 JSON은 에러",
+			content: model.Content{Subject: "{{.name}}", Content: "<p>본문</p>"},
+			vars:    `{"name":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderContent(&tt.content, tt.vars)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderContent() 에러 = %v, 에러 예상 = %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if rendered.Subject != tt.wantSubject {
+				t.Errorf("제목 = %q, 예상 = %q", rendered.Subject, tt.wantSubject)
+			}
+			if rendered.HTML != tt.wantHTML {
+				t.Errorf("본문 = %q, 예상 = %q", rendered.HTML, tt.wantHTML)
+			}
+			if rendered.Text != tt.wantText {
+				t.Errorf("텍스트 본문 = %q, 예상 = %q", rendered.Text, tt.wantText)
+			}
+		})
+	}
+}
+
+// TestValidateContent 템플릿 문법 검증
+func TestValidateContent(t *testing.T) {
+	if err := ValidateContent("{{.name}}님", "<p>{{.name}}</p>", "{{.name}}"); err != nil {
+		t.Errorf("정상 템플릿 검증 에러 = %v", err)
+	}
+	if err := ValidateContent("{{.name", "<p></p>", ""); err == nil {
+		t.Error("잘못된 제목 템플릿에서 에러를 예상했지만 nil이 반환됨")
+	}
+	if err := ValidateContent("제목", "<p>{{if}}</p>", ""); err == nil {
+		t.Error("잘못된 본문 템플릿에서 에러를 예상했지만 nil이 반환됨")
+	}
+	if err := ValidateContent("제목", "<p></p>", "{{end}}"); err == nil {
+		t.Error("잘못된 텍스트 템플릿에서 에러를 예상했지만 nil이 반환됨")
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// retryPolicy 일시적 발송 실패 재시도 정책
+type retryPolicy struct {
+	maxAttempts int           // 최대 발송 시도 횟수 (최초 발송 포함)
+	baseDelay   time.Duration // 첫 재시도 대기 시간
+	maxDelay    time.Duration // 최대 재시도 대기 시간
+}
+
+var (
+	retryPolicyInstance retryPolicy
+	retryPolicyOnce     sync.Once
+)
+
+// getRetryPolicy 환경 변수 기반 재시도 정책 반환 (싱글톤)
+func getRetryPolicy() retryPolicy {
+	retryPolicyOnce.Do(func() {
+		retryPolicyInstance = retryPolicy{
+			maxAttempts: config.GetEnvAsInt("SEND_MAX_ATTEMPTS", 5),
+			baseDelay:   config.GetEnvAsDuration("SEND_RETRY_BASE_DELAY", 30*time.Second),
+			maxDelay:    config.GetEnvAsDuration("SEND_RETRY_MAX_DELAY", 1*time.Hour),
+		}
+	})
+	return retryPolicyInstance
+}
+
+// shouldRetry 시도 횟수 기준 재시도 가능 여부
+func (p retryPolicy) shouldRetry(attempts int) bool {
+	return attempts < p.maxAttempts
+}
+
+// backoff 시도 횟수에 따른 지수 백오프 대기 시간 계산 (대기 시간의 절반 범위 지터 적용)
+func (p retryPolicy) backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := p.baseDelay
+	for i := 1; i < attempts && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRetryPolicyBackoff 지수 백오프 및 지터 범위 테스트
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 5, baseDelay: 10 * time.Second, maxDelay: 1 * time.Minute}
+
+	tests := []struct {
+		name     string        // 테스트 케이스 이름
+		attempts int           // 시도 횟수
+		wantMin  time.Duration // 최소 대기 시간
+		wantMax  time.Duration // 최대 대기 시간
+	}{
+		{"첫 번째 재시도", 1, 5 * time.Second, 10 * time.Second},
+		{"두 번째 재시도는 2배", 2, 10 * time.Second, 20 * time.Second},
+		{"세 번째 재시도는 4배", 3, 20 * time.Second, 40 * time.Second},
+		{"최대 대기 시간 제한", 10, 30 * time.Second, 1 * time.Minute},
+		{"0 이하 시도 횟수는 첫 재시도로 간주", 0, 5 * time.Second, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				d := policy.backoff(tt.attempts)
+				if d < tt.wantMin || d > tt.wantMax {
+					t.Fatalf("backoff(%d) = %v, 예상 범위 = [%v, %v]", tt.attempts, d, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
+
+// TestRetryPolicyShouldRetry 최대 시도 횟수 기준 재시도 여부 테스트
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 3}
+
+	tests := []struct {
+		attempts int  // 시도 횟수
+		want     bool // 재시도 가능 여부
+	}{
+		{1, true},
+		{2, true},
+		{3, false},
+		{4, false},
+	}
+
+	for _, tt := range tests {
+		if got := policy.shouldRetry(tt.attempts); got != tt.want {
+			t.Errorf("shouldRetry(%d) = %v, 예상 = %v", tt.attempts, got, tt.want)
+		}
+	}
+}
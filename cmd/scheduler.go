@@ -5,62 +5,81 @@ import (
 	"aws-ses-sender-go/model"
 	"context"
 	"log"
-	"strconv"
+	"sync"
 	"time"
+
+	"gorm.io/gorm"
 )
 
-// RunScheduler 스케줄러 실행 (이메일 발송 요청을 처리 대기열에 추가)
+// queueLane 우선순위별 발송 대기열
+type queueLane struct {
+	name     string              // 로그용 이름
+	priority int                 // 처리할 우선순위
+	ch       chan *model.Request // 발송 워커로 전달할 채널
+	interval time.Duration       // 처리 대상 조회 주기
+}
+
+// RunScheduler 스케줄러 실행 (우선순위별로 이메일 발송 요청을 처리 대기열에 추가)
 func RunScheduler(ctx context.Context) {
-	db := config.GetDB()
-	sendPerSecStr := config.GetEnv("EMAIL_RATE", "14")
-	sendPerSec, err := strconv.Atoi(sendPerSecStr)
-	if err != nil {
-		log.Fatalf("Invalid EMAIL_RATE: %v", err)
+	lanes := []queueLane{
+		{
+			name:     "high",
+			priority: model.EmailPriorityHigh,
+			ch:       highChan,
+			interval: config.GetEnvAsDuration("HIGH_PRIORITY_POLL_INTERVAL", 2*time.Second),
+		},
+		{
+			name:     "normal",
+			priority: model.EmailPriorityNormal,
+			ch:       reqChan,
+			interval: 1 * time.Minute,
+		},
+	}
+
+	var wg sync.WaitGroup
+	for _, lane := range lanes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runLane(ctx, lane)
+		}()
 	}
-	sendPerMin := sendPerSec * 60
+	wg.Wait()
+}
+
+// runLane 하나의 우선순위 대기열에 대해 주기적으로 처리 대상을 적재
+func runLane(ctx context.Context, lane queueLane) {
+	db := config.GetDB()
+	rc := getRateController()
 	batchSize := 1000
+	leaseDuration := getLeaseDuration()
 
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(lane.interval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			// 일일 발송 한도 소진 시 재개될 때까지 대기열 적재 중단
+			if rc.paused() != nil {
+				continue
+			}
+			// 조회 주기 동안 발송 가능한 수만큼 적재 (현재 발송 속도 기준)
+			budget := max(1, int(rc.limit()*lane.interval.Seconds()))
 			contents := make(map[uint]*model.Content)
 			totalQueued := 0
 
-			for i := 0; i < sendPerMin; i += batchSize {
+			for i := 0; i < budget; i += batchSize {
 				select {
 				case <-ctx.Done():
-					log.Printf("Scheduler interrupted, queued %d emails", totalQueued)
+					log.Printf("Scheduler interrupted (lane=%s), queued %d emails", lane.name, totalQueued)
 					return
 				default:
 				}
 
 				now := time.Now().UTC()
-				reqs := make([]*model.Request, 0, batchSize)
-
-				// 상태 업데이트 및 처리 대상 조회 (SQLite3 RETURNING)
-				err := db.Raw(`
-					UPDATE email_requests
-					SET status = ?, updated_at = ?
-					WHERE id IN (
-						SELECT id FROM email_requests
-						WHERE status = ?
-						  AND (scheduled_at <= ? OR scheduled_at IS NULL)
-						  AND deleted_at IS NULL
-						ORDER BY id ASC
-						LIMIT ?
-					)
-					RETURNING *
-				`,
-					model.EmailMsgStatusProcessing,
-					now,
-					model.EmailMsgStatusCreated,
-					now,
-					batchSize,
-				).Scan(&reqs).Error
-
+				reqs, err := claimRequests(db, lane.priority, now, now.Add(leaseDuration), min(batchSize, budget-i))
 				if err != nil {
 					log.Printf("Update Returning Error: %v", err)
 					break
@@ -70,7 +89,16 @@ func RunScheduler(ctx context.Context) {
 					break
 				}
 
-				for _, req := range reqs {
+				// 수신 거부 목록에 있는 주소는 발송 대기열에 넣지 않음
+				if reqs, err = dropSuppressed(db, reqs, now); err != nil {
+					log.Printf("Failed to check suppressions (lane=%s): %v", lane.name, err)
+				}
+				// 수신자가 수신 거부한 주소(토픽별 또는 전체)와 구독을 해지한 카테고리의 요청도 발송 대기열에 넣지 않음
+				if reqs, err = dropUnsubscribed(db, reqs); err != nil {
+					log.Printf("Failed to check unsubscribes (lane=%s): %v", lane.name, err)
+				}
+
+				for idx, req := range reqs {
 					if _, ok := contents[req.ContentId]; !ok {
 						content := &model.Content{}
 						db.First(content, req.ContentId)
@@ -78,19 +106,59 @@ func RunScheduler(ctx context.Context) {
 					}
 					req.Content = *contents[req.ContentId]
 
-					select {
-					case reqChan <- req:
-						totalQueued++
-					case <-ctx.Done():
-						log.Printf("Scheduler interrupted while queueing, queued %d emails", totalQueued)
-						return
+					// 종료 신호가 도착하면 채널에 넣지 못한 요청을 즉시 대기 상태로 반환
+					if ctx.Err() == nil {
+						select {
+						case lane.ch <- req:
+							totalQueued++
+							continue
+						case <-ctx.Done():
+						}
 					}
+					released, err := releaseRequests(db, reqs[idx:])
+					if err != nil {
+						log.Printf("Failed to release unqueued requests: %v", err)
+					}
+					log.Printf("Scheduler interrupted while queueing (lane=%s), queued %d emails, released %d",
+						lane.name, totalQueued, released)
+					return
 				}
 			}
 
 			if totalQueued > 0 {
-				log.Printf("Queued %d emails for sending", totalQueued)
+				log.Printf("Queued %d emails for sending (lane=%s)", totalQueued, lane.name)
 			}
 		}
 	}
 }
+
+// claimRequests 발송 가능한 요청을 처리 중 상태로 전환하고 리스 설정 후 반환 (SQLite3 RETURNING)
+func claimRequests(db *gorm.DB, priority int, now, lockedUntil time.Time, limit int) ([]*model.Request, error) {
+	reqs := make([]*model.Request, 0, limit)
+	err := db.Raw(`
+		UPDATE email_requests
+		SET status = ?, updated_at = ?, locked_until = ?, lease_owner = ?
+		WHERE id IN (
+			SELECT id FROM email_requests
+			WHERE status = ?
+			  AND priority = ?
+			  AND (scheduled_at <= ? OR scheduled_at IS NULL)
+			  AND (next_attempt_at <= ? OR next_attempt_at IS NULL)
+			  AND deleted_at IS NULL
+			ORDER BY id ASC
+			LIMIT ?
+		)
+		RETURNING *
+	`,
+		model.EmailMsgStatusProcessing,
+		now,
+		lockedUntil,
+		instanceID,
+		model.EmailMsgStatusCreated,
+		priority,
+		now,
+		now,
+		limit,
+	).Scan(&reqs).Error
+	return reqs, err
+}
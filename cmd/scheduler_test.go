@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"testing"
+	"time"
+)
+
+// TestClaimRequests 우선순위별 처리 대상 조회 및 리스 설정 검증
+func TestClaimRequests(t *testing.T) {
+	db := newTestDB(t)
+
+	bulk := createTestRequest(t, db, "bulk@example.com")
+	urgent := createTestRequest(t, db, "urgent@example.com")
+	if err := db.Model(&model.Request{}).Where("1 = 1").Update("status", model.EmailMsgStatusCreated).Error; err != nil {
+		t.Fatalf("상태 설정 실패: %v", err)
+	}
+	if err := db.Model(urgent).Update("priority", model.EmailPriorityHigh).Error; err != nil {
+		t.Fatalf("우선순위 설정 실패: %v", err)
+	}
+	now := time.Now().UTC()
+
+	reqs, err := claimRequests(db, model.EmailPriorityHigh, now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("claimRequests() 에러 = %v", err)
+	}
+	if len(reqs) != 1 || reqs[0].ID != urgent.ID {
+		t.Fatalf("우선 요청만 조회되어야 함: %+v", reqs)
+	}
+	if reqs[0].Status != model.EmailMsgStatusProcessing || reqs[0].LeaseOwner != instanceID {
+		t.Errorf("Status = %d, LeaseOwner = %q, 처리 중 상태와 리스 예상", reqs[0].Status, reqs[0].LeaseOwner)
+	}
+
+	// 이미 처리 중인 요청은 다시 조회되지 않음
+	reqs, err = claimRequests(db, model.EmailPriorityHigh, now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("claimRequests() 에러 = %v", err)
+	}
+	if len(reqs) != 0 {
+		t.Errorf("재조회 건수 = %d, 예상 = 0", len(reqs))
+	}
+
+	reqs, err = claimRequests(db, model.EmailPriorityNormal, now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("claimRequests() 에러 = %v", err)
+	}
+	if len(reqs) != 1 || reqs[0].ID != bulk.ID {
+		t.Errorf("일반 요청만 조회되어야 함: %+v", reqs)
+	}
+}
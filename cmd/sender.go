@@ -3,9 +3,10 @@ package cmd
 import (
 	"aws-ses-sender-go/config"
 	"aws-ses-sender-go/model"
-	"aws-ses-sender-go/pkg/aws"
+	"aws-ses-sender-go/pkg/mailer"
 	"context"
 	"fmt"
+	"html"
 	"log"
 	"strconv"
 	"sync"
@@ -13,36 +14,50 @@ import (
 	"time"
 
 	"golang.org/x/sync/semaphore"
-	"golang.org/x/time/rate"
 	"gorm.io/gorm"
 )
 
-// reqChan 스케줄러와 워커 간 요청 전달 채널
+// reqChan 스케줄러와 워커 간 요청 전달 채널 (일반 우선순위)
 var reqChan = make(chan *model.Request, 1000)
 
-// RunSender 이메일 발송 워커 실행
-func RunSender(ctx context.Context) {
-	rateStr := config.GetEnv("EMAIL_RATE", "14")
-	emailRate, err := strconv.Atoi(rateStr)
-	if err != nil {
-		log.Fatalf("Invalid EMAIL_RATE: %v", err)
-	}
+// highChan 우선 발송 요청 전달 채널
+var highChan = make(chan *model.Request, 1000)
 
-	maxConcurrent := config.GetEnvAsInt("MAX_CONCURRENT", emailRate*2)
+var (
+	sendSemaphore     *semaphore.Weighted
+	sendMaxConcurrent int64
+	sendSemaphoreOnce sync.Once
+)
 
-	sesClient, err := aws.NewSESClient(ctx)
+// getSendSemaphore 발송 동시 실행 수 제한 세마포어와 최대 동시 실행 수 반환 (싱글톤)
+func getSendSemaphore() (*semaphore.Weighted, int64) {
+	sendSemaphoreOnce.Do(func() {
+		emailRate, err := strconv.Atoi(config.GetEnv("EMAIL_RATE", "14"))
+		if err != nil {
+			log.Fatalf("Invalid EMAIL_RATE: %v", err)
+		}
+		sendMaxConcurrent = int64(config.GetEnvAsInt("MAX_CONCURRENT", emailRate*2))
+		sendSemaphore = semaphore.NewWeighted(sendMaxConcurrent)
+	})
+	return sendSemaphore, sendMaxConcurrent
+}
+
+// RunSender 이메일 발송 워커 실행
+func RunSender(ctx context.Context) {
+	m, err := GetMailer()
 	if err != nil {
-		log.Fatalf("Failed to create SES client: %v", err)
+		log.Fatalf("Failed to create mail provider: %v", err)
 	}
 
 	db := config.GetDB()
 
-	// 발송 속도 제어
-	limiter := rate.NewLimiter(rate.Limit(emailRate), emailRate)
-	// 동시 실행 수 제한
-	sem := semaphore.NewWeighted(int64(maxConcurrent))
+	// 발송 속도 제어 (계정 한도 기반 자동 조절)
+	rc := getRateController()
+	go rc.run(ctx)
+	// 동시 실행 수 제한 (즉시 발송 API와 공유)
+	sem, maxConcurrent := getSendSemaphore()
 
-	log.Printf("Sender started (rate=%d/sec, max_concurrent=%d)", emailRate, maxConcurrent)
+	log.Printf("Sender started (rate=%.2f/sec, max_concurrent=%d)", rc.limit(), maxConcurrent)
 
 	var sentCnt, failCnt atomic.Int64
 	now := time.Now()
@@ -74,57 +89,116 @@ func RunSender(ctx context.Context) {
 
 	var wg sync.WaitGroup
 
+	// 우선 대기열과 일반 대기열이 발송 속도를 가중치에 따라 나누어 사용
+	lanes := newLaneSelector(highChan, reqChan, config.GetEnvAsInt("HIGH_PRIORITY_WEIGHT", 4))
+
 	for {
-		select {
-		case <-ctx.Done():
+		req, err := lanes.next(ctx)
+		if err != nil {
 			log.Println("Sender shutting down, waiting for in-flight requests...")
 			shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			if err := sem.Acquire(shutdownCtx, int64(maxConcurrent)); err == nil {
-				sem.Release(int64(maxConcurrent))
+			if err := sem.Acquire(shutdownCtx, maxConcurrent); err == nil {
+				sem.Release(maxConcurrent)
 			}
 			cancel()
 			wg.Wait()
-			log.Printf("Sender stopped (sent=%d, failed=%d)", sentCnt.Load(), failCnt.Load())
+			released := drainQueue(db)
+			log.Printf("Sender stopped (sent=%d, failed=%d, released=%d)", sentCnt.Load(), failCnt.Load(), released)
 			return
+		}
+		if req == nil {
+			continue
+		}
 
-		case req := <-reqChan:
-			if req == nil {
-				continue
+		// 일일 발송 한도 소진 시 대기열을 반환하고 재개될 때까지 대기
+		if resume := rc.paused(); resume != nil {
+			requeue(db, req)
+			drainQueue(db)
+			select {
+			case <-resume:
+			case <-ctx.Done():
 			}
+			continue
+		}
 
-			if err := limiter.Wait(ctx); err != nil {
-				log.Printf("Rate limiter error: %v", err)
-				continue
-			}
+		if err := rc.limiter.Wait(ctx); err != nil {
+			log.Printf("Rate limiter error: %v", err)
+			requeue(db, req)
+			continue
+		}
 
-			if err := sem.Acquire(ctx, 1); err != nil {
-				log.Printf("Semaphore acquire error: %v", err)
-				continue
-			}
+		if err := sem.Acquire(ctx, 1); err != nil {
+			log.Printf("Semaphore acquire error: %v", err)
+			requeue(db, req)
+			continue
+		}
 
-			wg.Add(1)
-			go func(r *model.Request) {
-				defer wg.Done()
-				defer sem.Release(1)
-				defer func() {
-					if rec := recover(); rec != nil {
-						log.Printf("Panic recovered in sendEmail: %v", rec)
-						failCnt.Add(1)
-					}
-				}()
-
-				if err := sendEmail(ctx, r, sesClient, db); err != nil {
+		wg.Add(1)
+		go func(r *model.Request) {
+			defer wg.Done()
+			defer sem.Release(1)
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.Printf("Panic recovered in sendEmail: %v", rec)
 					failCnt.Add(1)
-				} else {
-					sentCnt.Add(1)
 				}
-			}(req)
+			}()
+
+			// 대기열에 있는 동안 리스가 만료되었거나 취소, 일시 중지된 요청은 발송하지 않음
+			ok, err := holdsLease(db, r, time.Now().UTC())
+			if err != nil {
+				log.Printf("Failed to check lease before sending (RequestID=%d): %v", r.ID, err)
+				requeue(db, r)
+				return
+			}
+			if !ok {
+				log.Printf("Skipping request whose lease is no longer held (RequestID=%d)", r.ID)
+				return
+			}
+
+			err = sendEmail(ctx, r, m, db)
+			rc.observe(err)
+			if err != nil {
+				failCnt.Add(1)
+			} else {
+				sentCnt.Add(1)
+			}
+		}(req)
+	}
+}
+
+// drainQueue 종료 시 채널에 남은 요청을 DB 대기 상태로 반환
+func drainQueue(db *gorm.DB) int64 {
+	var pending []*model.Request
+	for {
+		select {
+		case req := <-highChan:
+			if req != nil {
+				pending = append(pending, req)
+			}
+		case req := <-reqChan:
+			if req != nil {
+				pending = append(pending, req)
+			}
+		default:
+			released, err := releaseRequests(db, pending)
+			if err != nil {
+				log.Printf("Failed to release queued requests: %v", err)
+			}
+			return released
 		}
 	}
 }
 
+// requeue 발송하지 못한 단일 요청을 대기 상태로 반환
+func requeue(db *gorm.DB, req *model.Request) {
+	if _, err := releaseRequests(db, []*model.Request{req}); err != nil {
+		log.Printf("Failed to release request (RequestID=%d): %v", req.ID, err)
+	}
+}
+
 // sendEmail 이메일 발송 처리
-func sendEmail(ctx context.Context, req *model.Request, sesClient *aws.SES, db *gorm.DB) error {
+func sendEmail(ctx context.Context, req *model.Request, m mailer.Mailer, db *gorm.DB) error {
 	serverHost := config.GetEnv("SERVER_HOST", "http://localhost:3000")
 
 	if req.Content.ID == 0 {
@@ -132,42 +206,89 @@ func sendEmail(ctx context.Context, req *model.Request, sesClient *aws.SES, db *
 		return fmt.Errorf("content not loaded")
 	}
 
-	content := req.Content.Content
-	trackingPixel := fmt.Sprintf(`<img src="%s/v1/events/open?requestId=%d" width="1" height="1" alt="" />`,
-		serverHost, req.ID)
-	content += trackingPixel
+	// 수신자 변수로 제목과 본문 렌더링 (실패 시 재시도해도 동일하므로 영구 에러)
+	var msgId string
+	rendered, err := renderContent(&req.Content, req.Vars)
+	if err != nil {
+		err = mailer.Permanent(err)
+	} else {
+		content := rendered.HTML
+		if !req.Content.NoClickTracking {
+			content = rewriteLinks(content, serverHost, req.ID)
+		}
+		trackingPixel := fmt.Sprintf(`<img src="%s" width="1" height="1" alt="" />`,
+			html.EscapeString(openTrackingURL(serverHost, req.ID)))
+		content += trackingPixel
 
-	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+		// 종료 신호와 무관하게 진행 중인 발송과 상태 기록은 완료
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
 
-	msgId, err := sesClient.SendEmail(
-		sendCtx,
-		int(req.ID),
-		&req.Content.Subject,
-		&content,
-		[]string{req.To},
-	)
+		msgId, err = m.Send(sendCtx, &mailer.Message{
+			RequestID: req.ID,
+			To:        []string{req.To},
+			Subject:   rendered.Subject,
+			HTML:      content,
+			Text:      rendered.Text,
 
-	status := model.EmailMsgStatusSent
-	errMsg := ""
+			UnsubscribeURL: unsubscribeURL(serverHost, req.ID),
+		})
+	}
+
+	attempts := req.Attempts + 1
+	var nextAttemptAt *time.Time
+	updates := map[string]interface{}{
+		"message_id":      msgId,
+		"status":          model.EmailMsgStatusSent,
+		"error":           "",
+		"attempts":        attempts,
+		"next_attempt_at": nil,
+		"locked_until":    nil,
+		"lease_owner":     "",
+	}
 	if err != nil {
-		status = model.EmailMsgStatusFailed
-		errMsg = err.Error()
-		log.Printf("Failed to send email (RequestID=%d, To=%s): %v", req.ID, req.To, err)
+		updates["status"] = model.EmailMsgStatusFailed
+		updates["error"] = err.Error()
+
+		policy := getRetryPolicy()
+		if mailer.IsTransient(err) && policy.shouldRetry(attempts) {
+			// 일시적 실패는 백오프 후 스케줄러가 다시 가져가도록 대기 상태로 복귀
+			next := time.Now().UTC().Add(policy.backoff(attempts))
+			nextAttemptAt = &next
+			updates["status"] = model.EmailMsgStatusCreated
+			updates["next_attempt_at"] = next
+			log.Printf("Retrying email later (RequestID=%d, To=%s, attempt=%d/%d, next=%s): %v",
+				req.ID, req.To, attempts, policy.maxAttempts, next.Format(time.RFC3339), err)
+		} else {
+			log.Printf("Failed to send email (RequestID=%d, To=%s, kind=%s, attempt=%d): %v",
+				req.ID, req.To, mailer.KindOf(err), attempts, err)
+		}
 	}
 
-	updateErr := db.WithContext(ctx).Model(&model.Request{}).
-		Where("id = ?", req.ID).
-		Updates(map[string]interface{}{
-			"message_id": msgId,
-			"status":     status,
-			"error":      errMsg,
-		}).Error
-
-	if updateErr != nil {
-		log.Printf("Failed to update request status (RequestID=%d): %v", req.ID, updateErr)
-		return updateErr
+	// 발송된 요청은 결과를 그대로 기록하고, 실패한 요청은 리스를 보유한 경우만 기록
+	// 발송 중 리스가 만료되어 다른 워커가 가져갔거나 취소된 요청을 재시도 대기 상태로 되살리지 않음
+	query := db.WithContext(context.WithoutCancel(ctx)).Model(&model.Request{}).Where("id = ?", req.ID)
+	if err != nil {
+		query = leaseScope(db.WithContext(context.WithoutCancel(ctx)), req)
 	}
+	res := query.Updates(updates)
+	if res.Error != nil {
+		log.Printf("Failed to update request status (RequestID=%d): %v", req.ID, res.Error)
+		return res.Error
+	}
+	if err != nil && res.RowsAffected == 0 {
+		log.Printf("Request lease no longer held, not recording failure (RequestID=%d): %v", req.ID, err)
+		return err
+	}
+
+	// 저장된 상태를 호출자에게 반영
+	req.MessageId = msgId
+	req.Status = updates["status"].(int)
+	req.Error = updates["error"].(string)
+	req.Attempts = attempts
+	req.NextAttemptAt = nextAttemptAt
+	req.LockedUntil = nil
+	req.LeaseOwner = ""
 
 	return err
 }
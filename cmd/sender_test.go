@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeMailer 테스트용 발송 제공자 (AWS 호출 없이 발송 내역 기록)
+type fakeMailer struct {
+	mu     sync.Mutex
+	sent   []*mailer.Message
+	err    error
+	onSend func() // 발송 중 실행할 동작 (nil이면 없음)
+}
+
+func (f *fakeMailer) Send(_ context.Context, msg *mailer.Message) (string, error) {
+	if f.onSend != nil {
+		f.onSend()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return "", f.err
+	}
+	f.sent = append(f.sent, msg)
+	return "fake-message-id", nil
+}
+
+// newTestDB 테스트용 인메모리 DB 생성
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("테스트 DB 생성 실패: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("테스트 DB 인스턴스 조회 실패: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := model.AutoMigrate(db); err != nil {
+		t.Fatalf("테스트 DB 마이그레이션 실패: %v", err)
+	}
+	return db
+}
+
+// createTestRequest 테스트용 발송 요청 생성 (Content 포함, 현재 인스턴스가 리스 보유)
+func createTestRequest(t *testing.T, db *gorm.DB, to string) *model.Request {
+	t.Helper()
+	content := &model.Content{Subject: "테스트 제목", Content: "<p>테스트 본문</p>"}
+	if err := db.Create(content).Error; err != nil {
+		t.Fatalf("Content 생성 실패: %v", err)
+	}
+	now := time.Now().UTC()
+	lockedUntil := now.Add(time.Minute)
+	req := &model.Request{
+		To:          to,
+		ContentId:   content.ID,
+		ScheduledAt: &now,
+		Status:      model.EmailMsgStatusProcessing,
+		LockedUntil: &lockedUntil,
+		LeaseOwner:  instanceID,
+	}
+	if err := db.Create(req).Error; err != nil {
+		t.Fatalf("Request 생성 실패: %v", err)
+	}
+	req.Content = *content
+	return req
+}
+
+// TestSendEmail Mailer 인터페이스를 통한 발송 및 상태 업데이트 테스트
+func TestSendEmail(t *testing.T) {
+	tests := []struct {
+		name         string // 테스트 케이스 이름
+		attempts     int    // 기존 발송 시도 횟수
+		mailerErr    error  // 발송 제공자가 반환할 에러
+		wantStatus   int    // 예상 요청 상태
+		wantMsgId    string // 예상 메시지 ID
+		wantAttempts int    // 예상 발송 시도 횟수
+		wantNextAt   bool   // 재시도 시각 설정 여부
+	}{
+		{
+			name:         "발송 성공 시 Sent 상태와 메시지 ID 저장",
+			mailerErr:    nil,
+			wantStatus:   model.EmailMsgStatusSent,
+			wantMsgId:    "fake-message-id",
+			wantAttempts: 1,
+		},
+		{
+			name:         "영구 에러는 즉시 Failed 상태 저장",
+			mailerErr:    mailer.Permanent(errors.New("rejected")),
+			wantStatus:   model.EmailMsgStatusFailed,
+			wantMsgId:    "",
+			wantAttempts: 1,
+		},
+		{
+			name:         "일시적 에러는 재시도 대기 상태로 복귀",
+			mailerErr:    mailer.Transient(errors.New("throttled")),
+			wantStatus:   model.EmailMsgStatusCreated,
+			wantMsgId:    "",
+			wantAttempts: 1,
+			wantNextAt:   true,
+		},
+		{
+			name:         "재시도 횟수를 모두 소진하면 Failed 상태 저장",
+			attempts:     getRetryPolicy().maxAttempts - 1,
+			mailerErr:    mailer.Transient(errors.New("throttled")),
+			wantStatus:   model.EmailMsgStatusFailed,
+			wantMsgId:    "",
+			wantAttempts: getRetryPolicy().maxAttempts,
+		},
+		{
+			name:         "재시도 후 성공하면 재시도 시각 초기화",
+			attempts:     2,
+			mailerErr:    nil,
+			wantStatus:   model.EmailMsgStatusSent,
+			wantMsgId:    "fake-message-id",
+			wantAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			req := createTestRequest(t, db, "user@example.com")
+			req.Attempts = tt.attempts
+			m := &fakeMailer{err: tt.mailerErr}
+
+			err := sendEmail(context.Background(), req, m, db)
+			if (err != nil) != (tt.mailerErr != nil) {
+				t.Errorf("sendEmail() 에러 = %v, 예상 에러 = %v", err, tt.mailerErr)
+			}
+
+			var saved model.Request
+			if err := db.First(&saved, req.ID).Error; err != nil {
+				t.Fatalf("Request 조회 실패: %v", err)
+			}
+			if saved.Status != tt.wantStatus {
+				t.Errorf("Status = %d, 예상 = %d", saved.Status, tt.wantStatus)
+			}
+			if saved.MessageId != tt.wantMsgId {
+				t.Errorf("MessageId = %q, 예상 = %q", saved.MessageId, tt.wantMsgId)
+			}
+			if saved.Attempts != tt.wantAttempts {
+				t.Errorf("Attempts = %d, 예상 = %d", saved.Attempts, tt.wantAttempts)
+			}
+			if (saved.NextAttemptAt != nil) != tt.wantNextAt {
+				t.Errorf("NextAttemptAt = %v, 설정 예상 = %v", saved.NextAttemptAt, tt.wantNextAt)
+			}
+		})
+	}
+}
+
+// TestSendEmailLeaseLost 발송 중 리스가 만료되어 다른 인스턴스가 가져간 요청은 실패를 기록하지 않음
+func TestSendEmailLeaseLost(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	future := time.Now().UTC().Add(time.Hour)
+	setLease(t, db, req, model.EmailMsgStatusProcessing, &future, "other-instance")
+
+	m := &fakeMailer{err: mailer.Transient(errors.New("throttled"))}
+	if err := sendEmail(context.Background(), req, m, db); !mailer.IsTransient(err) {
+		t.Fatalf("sendEmail() 에러 = %v, 일시적 에러 예상", err)
+	}
+
+	saved := loadRequest(t, db, req.ID)
+	if saved.Status != model.EmailMsgStatusProcessing || saved.LeaseOwner != "other-instance" || saved.Attempts != 0 {
+		t.Errorf("status = %d, leaseOwner = %q, attempts = %d, 다른 인스턴스의 리스 유지 예상", saved.Status, saved.LeaseOwner, saved.Attempts)
+	}
+	if req.Status != model.EmailMsgStatusProcessing {
+		t.Errorf("req.Status = %d, 기록되지 않은 상태는 반영하지 않아야 함", req.Status)
+	}
+}
+
+// TestSendEmailMessage 발송 메시지에 트래킹 픽셀, 요청 ID, 서명된 수신 거부 URL이 포함되는지 검증
+func TestSendEmailMessage(t *testing.T) {
+	usePersistentTrackingKey(t, true)
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	m := &fakeMailer{}
+
+	if err := sendEmail(context.Background(), req, m, db); err != nil {
+		t.Fatalf("sendEmail() 에러 = %v", err)
+	}
+	if len(m.sent) != 1 {
+		t.Fatalf("발송 건수 = %d, 예상 = 1", len(m.sent))
+	}
+
+	msg := m.sent[0]
+	if msg.RequestID != req.ID {
+		t.Errorf("RequestID = %d, 예상 = %d", msg.RequestID, req.ID)
+	}
+	if len(msg.To) != 1 || msg.To[0] != "user@example.com" {
+		t.Errorf("To = %v, 예상 = [user@example.com]", msg.To)
+	}
+	if msg.Subject != "테스트 제목" {
+		t.Errorf("Subject = %q, 예상 = %q", msg.Subject, "테스트 제목")
+	}
+	if want := "/v1/events/open?requestId="; !strings.Contains(msg.HTML, want) {
+		t.Errorf("HTML에 트래킹 픽셀이 없음: %s", msg.HTML)
+	}
+	u, err := url.Parse(msg.UnsubscribeURL)
+	if err != nil || u.Path != "/v1/unsubscribe" || u.Query().Get("requestId") != strconv.FormatUint(uint64(req.ID), 10) {
+		t.Fatalf("UnsubscribeURL = %q", msg.UnsubscribeURL)
+	}
+	if !VerifyUnsubscribe(req.ID, u.Query().Get("sig")) {
+		t.Errorf("수신 거부 URL 서명 검증 실패: %s", msg.UnsubscribeURL)
+	}
+}
+
+// TestSendEmailWithoutTrackingKeys TRACKING_KEYS 없이 임시 키를 쓰면 재시작 후 동작하지 않는 수신 거부 URL을 싣지 않음
+func TestSendEmailWithoutTrackingKeys(t *testing.T) {
+	usePersistentTrackingKey(t, false)
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	m := &fakeMailer{}
+
+	if err := sendEmail(context.Background(), req, m, db); err != nil {
+		t.Fatalf("sendEmail() 에러 = %v", err)
+	}
+	if len(m.sent) != 1 || m.sent[0].UnsubscribeURL != "" {
+		t.Fatalf("발송 메시지 = %+v, 수신 거부 URL 없이 1건 발송 예상", m.sent)
+	}
+}
+
+// usePersistentTrackingKey 테스트 동안 서명 키가 TRACKING_KEYS로 설정된 것처럼(또는 임시 키인 것처럼) 동작
+func usePersistentTrackingKey(t *testing.T, persistent bool) {
+	t.Helper()
+	getTrackingSigner()
+	orig := trackingKeyTemporary
+	t.Cleanup(func() { trackingKeyTemporary = orig })
+	trackingKeyTemporary = !persistent
+}
+
+// TestSendEmailPersonalized 수신자 변수 렌더링 및 엄격 모드 실패 처리 검증
+func TestSendEmailPersonalized(t *testing.T) {
+	db := newTestDB(t)
+	m := &fakeMailer{}
+
+	req := createTestRequest(t, db, "user@example.com")
+	req.Content.Subject = "{{.name}}님 안녕하세요"
+	req.Content.Content = "<p>{{.code}}</p>"
+	req.Vars = `{"name":"홍길동","code":"123456"}`
+
+	if err := sendEmail(context.Background(), req, m, db); err != nil {
+		t.Fatalf("sendEmail() 에러 = %v", err)
+	}
+	if len(m.sent) != 1 {
+		t.Fatalf("발송 건수 = %d, 예상 = 1", len(m.sent))
+	}
+	if m.sent[0].Subject != "홍길동님 안녕하세요" || !strings.HasPrefix(m.sent[0].HTML, "<p>123456</p>") {
+		t.Errorf("렌더링 결과 = %q / %q", m.sent[0].Subject, m.sent[0].HTML)
+	}
+
+	// 엄격 모드에서 변수가 없으면 발송하지 않고 실패 처리
+	strict := createTestRequest(t, db, "strict@example.com")
+	strict.Content.Content = "<p>{{.code}}</p>"
+	strict.Content.Strict = true
+
+	err := sendEmail(context.Background(), strict, m, db)
+	if err == nil || mailer.IsTransient(err) {
+		t.Fatalf("sendEmail() 에러 = %v, 영구 에러 예상", err)
+	}
+	if len(m.sent) != 1 {
+		t.Errorf("렌더링 실패 요청이 발송됨: %d", len(m.sent))
+	}
+	if saved := loadRequest(t, db, strict.ID); saved.Status != model.EmailMsgStatusFailed {
+		t.Errorf("Status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusFailed)
+	}
+}
+
+// TestSendEmailWithSink 캡처 제공자 사용 시 합성 메시지 ID로 Sent 처리되는지 검증
+func TestSendEmailWithSink(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+	sink := mailer.NewMemorySink("sender@example.com", 10)
+
+	if err := sendEmail(context.Background(), req, sink, db); err != nil {
+		t.Fatalf("sendEmail() 에러 = %v", err)
+	}
+
+	var saved model.Request
+	if err := db.First(&saved, req.ID).Error; err != nil {
+		t.Fatalf("Request 조회 실패: %v", err)
+	}
+	if saved.Status != model.EmailMsgStatusSent {
+		t.Errorf("Status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusSent)
+	}
+	if saved.MessageId == "" {
+		t.Error("합성 메시지 ID가 저장되지 않음")
+	}
+	if _, _, err := sink.Get(saved.MessageId); err != nil {
+		t.Errorf("캡처된 메시지 조회 실패: %v", err)
+	}
+}
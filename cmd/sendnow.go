@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSendPaused 일일 발송 한도 소진으로 발송이 중지된 경우
+	ErrSendPaused = errors.New("sending paused: daily send quota exhausted")
+	// ErrSendQueued 발송 슬롯을 얻지 못해 요청을 비동기 발송으로 전환한 경우
+	ErrSendQueued = errors.New("send slot unavailable, request queued for asynchronous delivery")
+)
+
+// SendResult 즉시 발송 결과
+type SendResult struct {
+	RequestID uint   `json:"requestId"`
+	MessageID string `json:"messageId,omitempty"`
+	Status    string `json:"status"` // sent, retrying, failed, queued, suppressed, unsubscribed
+}
+
+// SendNow 요청을 저장한 뒤 스케줄러를 거치지 않고 즉시 발송
+// 발송 속도 제한과 동시 실행 수 제한은 비동기 발송 워커와 공유
+func SendNow(ctx context.Context, content *model.Content, req *model.Request) (*SendResult, error) {
+	m, err := GetMailer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mail provider: %w", err)
+	}
+	sem, _ := getSendSemaphore()
+	return sendNow(ctx, config.GetDB(), m, getRateController(), sem, content, req)
+}
+
+// sendNow 즉시 발송 처리 (의존성 주입 버전)
+func sendNow(ctx context.Context, db *gorm.DB, m mailer.Mailer, rc *rateController, sem *semaphore.Weighted,
+	content *model.Content, req *model.Request) (*SendResult, error) {
+	if rc.paused() != nil {
+		return nil, ErrSendPaused
+	}
+
+	// 스케줄러가 가져가지 않도록 처리 중 상태와 리스를 설정하여 저장
+	now := time.Now().UTC()
+	lockedUntil := now.Add(getLeaseDuration())
+	req.ScheduledAt = &now
+	req.Status = model.EmailMsgStatusProcessing
+	req.LockedUntil = &lockedUntil
+	req.LeaseOwner = instanceID
+
+	// 수신 거부 목록에 있거나 수신자가 수신 거부한 주소는 발송하지 않고 해당 상태로 저장
+	block, err := checkRecipient(db, req, now)
+	if err != nil {
+		return nil, err
+	}
+	if block != nil {
+		req.Status = block.status
+		req.Error = block.reason
+		req.LockedUntil = nil
+		req.LeaseOwner = ""
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(content).Error; err != nil {
+			return fmt.Errorf("failed to create content: %w", err)
+		}
+		req.ContentId = content.ID
+		if err := tx.Create(req).Error; err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Content = *content
+
+	if block != nil {
+		return &SendResult{RequestID: req.ID, Status: block.result}, block.err
+	}
+
+	result := &SendResult{RequestID: req.ID, Status: "queued"}
+
+	// 발송 슬롯을 얻지 못하면 (클라이언트 연결 종료 등) 스케줄러가 발송하도록 대기 상태로 반환
+	if err := rc.limiter.Wait(ctx); err != nil {
+		requeue(db, req)
+		return result, fmt.Errorf("%w: %w", ErrSendQueued, err)
+	}
+	if err := sem.Acquire(ctx, 1); err != nil {
+		requeue(db, req)
+		return result, fmt.Errorf("%w: %w", ErrSendQueued, err)
+	}
+	defer sem.Release(1)
+
+	err = sendEmail(ctx, req, m, db)
+	rc.observe(err)
+
+	result.MessageID = req.MessageId
+	switch req.Status {
+	case model.EmailMsgStatusSent:
+		result.Status = "sent"
+	case model.EmailMsgStatusCreated:
+		result.Status = "retrying"
+	default:
+		result.Status = "failed"
+	}
+	if err != nil {
+		log.Printf("Synchronous send failed (RequestID=%d, status=%s): %v", req.ID, result.Status, err)
+	}
+	return result, err
+}
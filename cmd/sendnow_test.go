@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// newSendNowRequest 즉시 발송 테스트용 컨텐츠와 요청 생성 (저장 전)
+func newSendNowRequest() (*model.Content, *model.Request) {
+	return &model.Content{Subject: "인증 코드", Content: "<p>123456</p>"},
+		&model.Request{To: "user@example.com", Priority: model.EmailPriorityHigh}
+}
+
+// TestSendNow 즉시 발송 결과와 저장 상태 검증
+func TestSendNow(t *testing.T) {
+	tests := []struct {
+		name          string // 테스트 케이스 이름
+		mailerErr     error  // 발송 제공자가 반환할 에러
+		wantStatus    string // 예상 결과 상태
+		wantDBStatus  int    // 예상 저장 상태
+		wantMessageID string // 예상 메시지 ID
+	}{
+		{
+			name:          "발송 성공 시 메시지 ID 반환",
+			wantStatus:    "sent",
+			wantDBStatus:  model.EmailMsgStatusSent,
+			wantMessageID: "fake-message-id",
+		},
+		{
+			name:         "영구 에러는 실패 처리",
+			mailerErr:    mailer.Permanent(errors.New("rejected")),
+			wantStatus:   "failed",
+			wantDBStatus: model.EmailMsgStatusFailed,
+		},
+		{
+			name:         "일시적 에러는 재시도 대기",
+			mailerErr:    mailer.Transient(errors.New("timeout")),
+			wantStatus:   "retrying",
+			wantDBStatus: model.EmailMsgStatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			m := &fakeMailer{err: tt.mailerErr}
+			rc := newRateController(100, nil, 0.9, time.Minute)
+			content, req := newSendNowRequest()
+
+			result, err := sendNow(context.Background(), db, m, rc, semaphore.NewWeighted(1), content, req)
+			if !errors.Is(err, tt.mailerErr) {
+				t.Fatalf("sendNow() 에러 = %v, 예상 = %v", err, tt.mailerErr)
+			}
+			if result.Status != tt.wantStatus || result.MessageID != tt.wantMessageID {
+				t.Errorf("결과 = %+v, 예상 상태 = %s, 메시지 ID = %q", result, tt.wantStatus, tt.wantMessageID)
+			}
+
+			saved := loadRequest(t, db, result.RequestID)
+			if saved.Status != tt.wantDBStatus {
+				t.Errorf("저장된 Status = %d, 예상 = %d", saved.Status, tt.wantDBStatus)
+			}
+			if saved.MessageId != tt.wantMessageID || saved.ContentId == 0 || saved.Priority != model.EmailPriorityHigh {
+				t.Errorf("저장된 요청 = %+v", saved)
+			}
+		})
+	}
+}
+
+// TestSendNowUnavailable 발송 불가 상황의 에러 처리 검증
+func TestSendNowUnavailable(t *testing.T) {
+	t.Run("일일 한도 소진 시 저장하지 않고 에러 반환", func(t *testing.T) {
+		db := newTestDB(t)
+		rc := newRateController(100, nil, 0.9, time.Minute)
+		rc.applyQuota(&mailer.Quota{MaxSendRate: 100, Max24HourSend: 10, SentLast24Hours: 10})
+		content, req := newSendNowRequest()
+
+		_, err := sendNow(context.Background(), db, &fakeMailer{}, rc, semaphore.NewWeighted(1), content, req)
+		if !errors.Is(err, ErrSendPaused) {
+			t.Fatalf("sendNow() 에러 = %v, 예상 = %v", err, ErrSendPaused)
+		}
+		var cnt int64
+		db.Model(&model.Request{}).Count(&cnt)
+		if cnt != 0 {
+			t.Errorf("저장된 요청 수 = %d, 예상 = 0", cnt)
+		}
+	})
+
+	t.Run("발송 슬롯 대기 중 취소되면 비동기 발송으로 전환", func(t *testing.T) {
+		db := newTestDB(t)
+		m := &fakeMailer{}
+		sem := semaphore.NewWeighted(1)
+		sem.Acquire(context.Background(), 1)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		content, req := newSendNowRequest()
+
+		result, err := sendNow(ctx, db, m, newRateController(100, nil, 0.9, time.Minute), sem, content, req)
+		if !errors.Is(err, ErrSendQueued) {
+			t.Fatalf("sendNow() 에러 = %v, 예상 = %v", err, ErrSendQueued)
+		}
+		if result.Status != "queued" || len(m.sent) != 0 {
+			t.Errorf("결과 = %+v, 발송 수 = %d", result, len(m.sent))
+		}
+		if saved := loadRequest(t, db, result.RequestID); saved.Status != model.EmailMsgStatusCreated || saved.LeaseOwner != "" {
+			t.Errorf("저장된 Status = %d, LeaseOwner = %q, 대기 상태 예상", saved.Status, saved.LeaseOwner)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/sns"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// subscriptionConfirmer SNS 구독 확인 (테스트에서 교체 가능)
+type subscriptionConfirmer interface {
+	Confirm(ctx context.Context, subscribeURL string) (string, error)
+}
+
+var (
+	snsVerifierInstance *sns.Verifier
+	snsVerifierOnce     sync.Once
+)
+
+// getSNSVerifier SNS 메시지 검증기 반환 (싱글톤)
+// SNS_TOPIC_ARNS는 쉼표로 구분하며 비어 있으면 모든 알림을 거부 (구독 메시지는 확인 대기 상태로 기록)
+func getSNSVerifier() *sns.Verifier {
+	snsVerifierOnce.Do(func() {
+		topics := snsTopicArns()
+		if len(topics) == 0 {
+			log.Println("SNS_TOPIC_ARNS is not set, rejecting all SNS notifications")
+		}
+		snsVerifierInstance = sns.NewVerifier(&sns.HTTPCertFetcher{}, topics)
+	})
+	return snsVerifierInstance
+}
+
+// snsTopicArns SNS_TOPIC_ARNS에 설정된 허용 TopicArn 목록
+func snsTopicArns() []string {
+	var topics []string
+	for _, arn := range strings.Split(config.GetEnv("SNS_TOPIC_ARNS", ""), ",") {
+		if arn = strings.TrimSpace(arn); arn != "" {
+			topics = append(topics, arn)
+		}
+	}
+	return topics
+}
+
+// VerifySNS SNS 메시지의 서명과 TopicArn 검증
+func VerifySNS(ctx context.Context, msg *sns.Message) error {
+	return getSNSVerifier().Verify(ctx, msg)
+}
+
+// HandleSubscription 구독 확인/해지 메시지 처리 (서명 검증 이후 호출)
+// SNS_TOPIC_ARNS에 명시된 토픽만 자동으로 구독을 확인하고, 그 외 토픽은 확인 대기 상태로 기록
+func HandleSubscription(ctx context.Context, msg *sns.Message) (*model.SNSSubscription, error) {
+	autoConfirm := getSNSVerifier().Allowlisted(msg.TopicArn)
+	return handleSubscription(ctx, config.GetDB(), &sns.Confirmer{}, autoConfirm, msg)
+}
+
+// handleSubscription 구독 메시지 처리 (의존성 주입 버전)
+func handleSubscription(ctx context.Context, db *gorm.DB, confirmer subscriptionConfirmer, autoConfirm bool,
+	msg *sns.Message) (*model.SNSSubscription, error) {
+	now := time.Now().UTC()
+
+	switch msg.Type {
+	case sns.TypeSubscriptionConfirmation:
+		status, arn, lastErr := model.SNSSubscriptionPending, "", ""
+		if !autoConfirm {
+			log.Printf("SNS subscription for %s is not allowlisted, confirm manually: %s", msg.TopicArn, msg.SubscribeURL)
+		} else if err := sns.ValidateSubscribeURL(msg.SubscribeURL); err != nil {
+			status, lastErr = model.SNSSubscriptionFailed, err.Error()
+		} else if arn, err = confirmer.Confirm(ctx, msg.SubscribeURL); err != nil {
+			status, lastErr = model.SNSSubscriptionFailed, err.Error()
+		} else {
+			status = model.SNSSubscriptionConfirmed
+			log.Printf("SNS subscription confirmed (topic=%s, subscription=%s)", msg.TopicArn, arn)
+		}
+		if lastErr != "" {
+			log.Printf("Failed to confirm SNS subscription (topic=%s): %s", msg.TopicArn, lastErr)
+		}
+
+		return model.SaveSNSSubscription(db, msg.TopicArn, func(sub *model.SNSSubscription) {
+			sub.Status = status
+			sub.SubscribeURL = msg.SubscribeURL
+			sub.LastError = lastErr
+			if status == model.SNSSubscriptionConfirmed {
+				sub.SubscriptionArn = arn
+				sub.ConfirmedAt = &now
+				sub.UnsubscribedAt = nil
+			}
+		})
+	case sns.TypeUnsubscribeConfirmation:
+		// 해지는 다시 구독하지 않고 상태만 기록 (재구독이 필요하면 SubscribeURL 사용)
+		log.Printf("SNS subscription unsubscribed (topic=%s)", msg.TopicArn)
+		return model.SaveSNSSubscription(db, msg.TopicArn, func(sub *model.SNSSubscription) {
+			sub.Status = model.SNSSubscriptionUnsubscribed
+			sub.SubscribeURL = msg.SubscribeURL
+			sub.LastError = ""
+			sub.UnsubscribedAt = &now
+		})
+	default:
+		return nil, fmt.Errorf("unsupported SNS subscription message type: %s", msg.Type)
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/sns"
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeConfirmer 테스트용 구독 확인 (SNS 호출 없이 결과 반환)
+type fakeConfirmer struct {
+	arn   string
+	err   error
+	calls int
+}
+
+func (f *fakeConfirmer) Confirm(_ context.Context, _ string) (string, error) {
+	f.calls++
+	return f.arn, f.err
+}
+
+// TestHandleSubscription SNS 구독 확인/해지 처리 테스트
+func TestHandleSubscription(t *testing.T) {
+	const topic = "arn:aws:sns:us-east-1:123456789012:ses-events"
+	const subscribeURL = "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=t"
+
+	tests := []struct {
+		name        string // 테스트 케이스 이름
+		msgType     string // SNS 메시지 유형
+		url         string // SubscribeURL
+		autoConfirm bool   // 허용 목록에 명시된 토픽 여부
+		confirmErr  error  // 구독 확인 에러
+		wantStatus  string // 예상 구독 상태
+		wantCalls   int    // 예상 구독 확인 호출 수
+	}{
+		{"허용된 토픽 자동 확인", sns.TypeSubscriptionConfirmation, subscribeURL, true, nil, model.SNSSubscriptionConfirmed, 1},
+		{"허용 목록에 없는 토픽은 확인 대기", sns.TypeSubscriptionConfirmation, subscribeURL, false, nil, model.SNSSubscriptionPending, 0},
+		{"구독 확인 실패", sns.TypeSubscriptionConfirmation, subscribeURL, true, errors.New("expired token"), model.SNSSubscriptionFailed, 1},
+		{"SNS 도메인이 아닌 확인 URL", sns.TypeSubscriptionConfirmation, "https://evil.example/confirm", true, nil, model.SNSSubscriptionFailed, 0},
+		{"구독 해지", sns.TypeUnsubscribeConfirmation, subscribeURL, true, nil, model.SNSSubscriptionUnsubscribed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			confirmer := &fakeConfirmer{arn: topic + ":sub-1", err: tt.confirmErr}
+			msg := &sns.Message{Type: tt.msgType, TopicArn: topic, SubscribeURL: tt.url}
+
+			sub, err := handleSubscription(context.Background(), db, confirmer, tt.autoConfirm, msg)
+			if err != nil {
+				t.Fatalf("handleSubscription() 에러 = %v", err)
+			}
+			if sub.Status != tt.wantStatus {
+				t.Errorf("구독 상태 = %s, 예상 = %s", sub.Status, tt.wantStatus)
+			}
+			if confirmer.calls != tt.wantCalls {
+				t.Errorf("구독 확인 호출 수 = %d, 예상 = %d", confirmer.calls, tt.wantCalls)
+			}
+
+			subs, err := model.ListSNSSubscriptions(db)
+			if err != nil || len(subs) != 1 || subs[0].Status != tt.wantStatus {
+				t.Errorf("저장된 구독 = %+v (%v), 예상 상태 = %s", subs, err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// TestHandleSubscriptionResubscribe 해지 후 다시 구독하면 같은 토픽 행을 갱신
+func TestHandleSubscriptionResubscribe(t *testing.T) {
+	db := newTestDB(t)
+	const topic = "arn:aws:sns:us-east-1:123456789012:ses-events"
+	confirm := &sns.Message{Type: sns.TypeSubscriptionConfirmation, TopicArn: topic,
+		SubscribeURL: "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=t"}
+	confirmer := &fakeConfirmer{arn: topic + ":sub-1"}
+
+	for _, msg := range []*sns.Message{confirm, {Type: sns.TypeUnsubscribeConfirmation, TopicArn: topic}, confirm} {
+		if _, err := handleSubscription(context.Background(), db, confirmer, true, msg); err != nil {
+			t.Fatalf("handleSubscription() 에러 = %v", err)
+		}
+	}
+
+	subs, _ := model.ListSNSSubscriptions(db)
+	if len(subs) != 1 {
+		t.Fatalf("구독 수 = %d, 예상 = 1", len(subs))
+	}
+	if subs[0].Status != model.SNSSubscriptionConfirmed || subs[0].UnsubscribedAt != nil || subs[0].ConfirmedAt == nil {
+		t.Errorf("재구독 상태 = %+v, 확인 완료 상태여야 함", subs[0])
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/sesevent"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrRecipientSuppressed 수신 주소가 수신 거부 목록에 있는 경우
+var ErrRecipientSuppressed = errors.New("recipient is on the suppression list")
+
+// suppressionTTL 자동 등록된 수신 거부의 유지 기간 (0이면 만료 없음)
+func suppressionTTL(reason string) time.Duration {
+	switch reason {
+	case model.SuppressionReasonBounce:
+		return config.GetEnvAsDuration("SUPPRESSION_BOUNCE_TTL", 0)
+	case model.SuppressionReasonComplaint:
+		return config.GetEnvAsDuration("SUPPRESSION_COMPLAINT_TTL", 0)
+	}
+	return 0
+}
+
+// suppressFromEvent 영구 반송과 수신 거부 신고 이벤트의 수신자를 수신 거부 목록에 등록
+func suppressFromEvent(tx *gorm.DB, ev *sesevent.Event, result *model.Result, now time.Time) error {
+	var reason string
+	switch {
+	case ev.Bounce != nil && ev.Bounce.BounceType == sesevent.BouncePermanent:
+		reason = model.SuppressionReasonBounce
+	case ev.Complaint != nil:
+		reason = model.SuppressionReasonComplaint
+	default:
+		return nil
+	}
+
+	var expiresAt *time.Time
+	if ttl := suppressionTTL(reason); ttl > 0 {
+		t := now.Add(ttl)
+		expiresAt = &t
+	}
+
+	for _, r := range ev.Recipients() {
+		if strings.TrimSpace(r.EmailAddress) == "" {
+			continue
+		}
+		detail := r.DiagnosticCode
+		if ev.Bounce != nil {
+			detail = strings.TrimSpace(fmt.Sprintf("%s/%s %s", ev.Bounce.BounceType, ev.Bounce.BounceSubType, r.DiagnosticCode))
+		} else if ev.Complaint.ComplaintFeedbackType != "" {
+			detail = ev.Complaint.ComplaintFeedbackType
+		}
+
+		s := &model.Suppression{
+			Email:     r.EmailAddress,
+			Reason:    reason,
+			Source:    model.SuppressionSourceEvent,
+			ResultId:  &result.ID,
+			MessageId: result.MessageId,
+			Detail:    detail,
+			ExpiresAt: expiresAt,
+		}
+		if err := model.SaveSuppression(tx, s); err != nil {
+			return err
+		}
+		log.Printf("Suppressed %s (reason=%s, requestId=%d)", s.Email, reason, result.RequestId)
+	}
+	return nil
+}
+
+// dropSuppressed 수신 거부 목록에 있는 요청을 수신 거부 상태로 전환하고 나머지 요청만 반환
+func dropSuppressed(db *gorm.DB, reqs []*model.Request, now time.Time) ([]*model.Request, error) {
+	emails := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		emails = append(emails, req.To)
+	}
+	suppressed, err := model.ActiveSuppressions(db, emails, now)
+	if err != nil {
+		return reqs, err
+	}
+	if len(suppressed) == 0 {
+		return reqs, nil
+	}
+
+	kept := reqs[:0]
+	for _, req := range reqs {
+		s, ok := suppressed[model.NormalizeEmail(req.To)]
+		if !ok {
+			kept = append(kept, req)
+			continue
+		}
+		if err := markSkipped(db, req, model.EmailMsgStatusSuppressed, fmt.Sprintf("suppressed: %s", s.Reason)); err != nil {
+			log.Printf("Failed to mark request as suppressed (RequestID=%d): %v", req.ID, err)
+		}
+	}
+	return kept, nil
+}
+
+// markSkipped 요청을 발송하지 않고 지정한 상태로 종료 (리스 해제)
+func markSkipped(db *gorm.DB, req *model.Request, status int, reason string) error {
+	req.Status = status
+	req.Error = reason
+	return db.Model(&model.Request{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+		"status":       req.Status,
+		"error":        req.Error,
+		"locked_until": nil,
+		"lease_owner":  "",
+	}).Error
+}
+
+// recipientBlock 즉시 발송하지 않는 요청의 상태와 사유
+type recipientBlock struct {
+	status int    // 저장할 요청 상태
+	result string // 즉시 발송 결과 상태 (suppressed, unsubscribed)
+	reason string // 요청 에러 메시지
+	err    error  // 반환할 에러
+}
+
+// checkRecipient 수신 거부 목록, 수신자 수신 거부와 카테고리 구독 해지 확인 (발송 가능하면 nil)
+func checkRecipient(db *gorm.DB, req *model.Request, now time.Time) (*recipientBlock, error) {
+	suppressed, err := model.ActiveSuppressions(db, []string{req.To}, now)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := suppressed[model.NormalizeEmail(req.To)]; ok {
+		return &recipientBlock{
+			status: model.EmailMsgStatusSuppressed,
+			result: "suppressed",
+			reason: fmt.Sprintf("suppressed: %s", s.Reason),
+			err:    fmt.Errorf("%w: %s (%s)", ErrRecipientSuppressed, req.To, s.Reason),
+		}, nil
+	}
+
+	unsubscribed, err := model.UnsubscribedTopics(db, []string{req.To})
+	if err != nil {
+		return nil, err
+	}
+	optedOut, err := model.OptedOutCategories(db, []string{req.To})
+	if err != nil {
+		return nil, err
+	}
+	email := model.NormalizeEmail(req.To)
+	if reason := unsubscribedReason(req, req.Category, unsubscribed[email], optedOut[email]); reason != "" {
+		return &recipientBlock{
+			status: model.EmailMsgStatusUnsubscribed,
+			result: "unsubscribed",
+			reason: reason,
+			err:    fmt.Errorf("%w: %s (%s)", ErrRecipientUnsubscribed, req.To, strings.TrimPrefix(reason, "unsubscribed: ")),
+		}, nil
+	}
+	return nil, nil
+}
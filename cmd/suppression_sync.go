@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/aws"
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// sesSuppressionSyncName SES 계정 수신 거부 목록 동기화 상태 이름
+	sesSuppressionSyncName = "ses_suppressions"
+	// sesSuppressionPushBatch 한 번의 동기화에서 SES로 반영할 최대 추가/삭제 수
+	sesSuppressionPushBatch = 1000
+	// sesSuppressionOverlap 증분 조회 시작 시각을 앞당기는 시간 (SES와 서버 시계 차이 보정)
+	sesSuppressionOverlap = 5 * time.Minute
+)
+
+// suppressionList SES 계정 수신 거부 목록 (테스트에서 가짜 목록으로 교체 가능)
+type suppressionList interface {
+	ListSuppressedDestinations(ctx context.Context, since time.Time, nextToken string) ([]aws.SuppressedDestination, string, error)
+	PutSuppressedDestination(ctx context.Context, email, reason string) error
+	DeleteSuppressedDestination(ctx context.Context, email string) error
+}
+
+// suppressionSyncer 로컬 수신 거부 목록과 SES 계정 수신 거부 목록 동기화
+type suppressionSyncer struct {
+	db   *gorm.DB
+	ses  suppressionList
+	full bool // true면 매번 전체 목록 조회, false면 마지막 동기화 이후 변경분만 조회 (StartDate)
+}
+
+// RunSuppressionSync SES 계정 수신 거부 목록 주기적 동기화 (SES_SUPPRESSION_SYNC_INTERVAL이 없거나 SES 제공자가 아니면 실행하지 않음)
+func RunSuppressionSync(ctx context.Context) {
+	interval := config.GetEnvAsDuration("SES_SUPPRESSION_SYNC_INTERVAL", 0)
+	if interval <= 0 {
+		return
+	}
+
+	m, err := GetMailer()
+	if err != nil {
+		log.Printf("Failed to start SES suppression sync: %v", err)
+		return
+	}
+	ses, ok := m.(*aws.SES)
+	if !ok {
+		log.Println("SES suppression sync requires MAIL_PROVIDER=ses, skipping")
+		return
+	}
+
+	s := &suppressionSyncer{
+		db:   config.GetDB(),
+		ses:  ses,
+		full: strings.EqualFold(config.GetEnv("SES_SUPPRESSION_SYNC_MODE", "incremental"), "full"),
+	}
+	log.Printf("SES suppression sync started (interval=%s, full=%t)", interval, s.full)
+
+	run := func() {
+		if err := s.sync(ctx, time.Now().UTC()); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to sync SES suppression list: %v", err)
+		}
+	}
+	run()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// sync 로컬 변경분을 SES에 먼저 반영한 뒤 SES 목록을 로컬로 가져오고 결과를 동기화 상태에 기록
+func (s *suppressionSyncer) sync(ctx context.Context, now time.Time) error {
+	err := s.push(ctx, now)
+	if err == nil {
+		err = s.pull(ctx, now)
+	}
+
+	_, stateErr := model.SaveSyncState(s.db, sesSuppressionSyncName, func(state *model.SyncState) {
+		state.LastRunAt = &now
+		state.LastError = ""
+		if err != nil {
+			state.LastError = err.Error()
+			return
+		}
+		state.Cursor = &now
+	})
+	if err != nil {
+		return err
+	}
+	return stateErr
+}
+
+// push 로컬에서 추가한 항목은 SES 목록에 추가하고 해제한 항목은 SES 목록에서 삭제
+// 개별 주소 실패는 기록만 하고 다음 동기화에서 다시 시도
+func (s *suppressionSyncer) push(ctx context.Context, now time.Time) error {
+	additions, err := model.PendingSESAdditions(s.db, sesSuppressionPushBatch)
+	if err != nil {
+		return err
+	}
+	removals, err := model.PendingSESRemovals(s.db, sesSuppressionPushBatch)
+	if err != nil {
+		return err
+	}
+
+	added, removed := 0, 0
+	for _, sup := range additions {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.ses.PutSuppressedDestination(ctx, sup.Email, sesSuppressionReason(sup.Reason)); err != nil {
+			log.Printf("Failed to push suppression to SES (email=%s): %v", sup.Email, err)
+			continue
+		}
+		synced, err := model.MarkSESSynced(s.db, &sup, now)
+		if err != nil {
+			return err
+		}
+		if !synced {
+			log.Printf("Suppression changed while pushing to SES, retrying next sync (email=%s)", sup.Email)
+			continue
+		}
+		added++
+	}
+	for _, sup := range removals {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.ses.DeleteSuppressedDestination(ctx, sup.Email); err != nil {
+			log.Printf("Failed to remove suppression from SES (email=%s): %v", sup.Email, err)
+			continue
+		}
+		synced, err := model.MarkSESSynced(s.db, &sup, now)
+		if err != nil {
+			return err
+		}
+		if !synced {
+			log.Printf("Suppression changed while pushing to SES, retrying next sync (email=%s)", sup.Email)
+			continue
+		}
+		removed++
+	}
+
+	if added > 0 || removed > 0 {
+		log.Printf("Pushed suppressions to SES (added=%d, removed=%d)", added, removed)
+	}
+	return nil
+}
+
+// pull SES 목록을 페이지 단위로 조회하여 로컬 목록에 반영 (증분 모드는 마지막 동기화 이후 변경분만)
+func (s *suppressionSyncer) pull(ctx context.Context, now time.Time) error {
+	var since time.Time
+	if !s.full {
+		state, err := model.GetSyncState(s.db, sesSuppressionSyncName)
+		if err != nil {
+			return err
+		}
+		if state.Cursor != nil {
+			since = state.Cursor.Add(-sesSuppressionOverlap)
+		}
+	}
+
+	seen, imported := 0, 0
+	token := ""
+	for {
+		page, next, err := s.ses.ListSuppressedDestinations(ctx, since, token)
+		if err != nil {
+			return err
+		}
+		for _, d := range page {
+			reason := strings.ToUpper(d.Reason)
+			if reason != model.SuppressionReasonBounce && reason != model.SuppressionReasonComplaint {
+				continue
+			}
+			changed, err := model.ApplySESSuppression(s.db, d.Email, reason, d.LastUpdateTime, now)
+			if err != nil {
+				return err
+			}
+			seen++
+			if changed {
+				imported++
+			}
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	if imported > 0 {
+		log.Printf("Imported suppressions from SES (imported=%d, listed=%d, since=%s)", imported, seen, formatSince(since))
+	}
+	return nil
+}
+
+// sesSuppressionReason 로컬 수신 거부 사유를 SES 사유로 변환 (SES는 BOUNCE, COMPLAINT만 지원하므로 MANUAL은 BOUNCE)
+func sesSuppressionReason(reason string) string {
+	if reason == model.SuppressionReasonComplaint {
+		return model.SuppressionReasonComplaint
+	}
+	return model.SuppressionReasonBounce
+}
+
+// formatSince 로그용 증분 조회 시작 시각 (전체 조회면 all)
+func formatSince(since time.Time) string {
+	if since.IsZero() {
+		return "all"
+	}
+	return since.Format(time.RFC3339)
+}
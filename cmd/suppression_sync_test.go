@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/aws"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeSuppressionList 테스트용 SES 계정 수신 거부 목록 (페이지당 2건)
+type fakeSuppressionList struct {
+	entries []aws.SuppressedDestination
+	since   []time.Time       // 조회 시 받은 StartDate
+	put     map[string]string // 추가된 주소와 사유
+	deleted []string          // 삭제된 주소
+	listErr error
+	onPut   func(email string) // 추가 중 실행할 동작 (nil이면 없음)
+}
+
+func (f *fakeSuppressionList) ListSuppressedDestinations(_ context.Context, since time.Time, nextToken string) ([]aws.SuppressedDestination, string, error) {
+	if f.listErr != nil {
+		return nil, "", f.listErr
+	}
+	if nextToken == "" {
+		f.since = append(f.since, since)
+	}
+	var matched []aws.SuppressedDestination
+	for _, e := range f.entries {
+		if since.IsZero() || !e.LastUpdateTime.Before(since) {
+			matched = append(matched, e)
+		}
+	}
+	start, _ := strconv.Atoi(nextToken)
+	end := min(start+2, len(matched))
+	next := ""
+	if end < len(matched) {
+		next = strconv.Itoa(end)
+	}
+	return matched[start:end], next, nil
+}
+
+func (f *fakeSuppressionList) PutSuppressedDestination(_ context.Context, email, reason string) error {
+	if f.put == nil {
+		f.put = make(map[string]string)
+	}
+	f.put[email] = reason
+	if f.onPut != nil {
+		f.onPut(email)
+	}
+	return nil
+}
+
+func (f *fakeSuppressionList) DeleteSuppressedDestination(_ context.Context, email string) error {
+	f.deleted = append(f.deleted, email)
+	return nil
+}
+
+// TestSuppressionSyncPush 로컬 추가/해제가 SES 목록에 반영되고 반영 완료로 기록되는지 테스트
+func TestSuppressionSyncPush(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Now().UTC()
+	future := now.Add(time.Hour)
+	for _, s := range []*model.Suppression{
+		{Email: "manual@example.com", Reason: model.SuppressionReasonManual, Source: model.SuppressionSourceAPI},
+		{Email: "complaint@example.com", Reason: model.SuppressionReasonComplaint, Source: model.SuppressionSourceImport},
+		{Email: "expiring@example.com", Reason: model.SuppressionReasonManual, Source: model.SuppressionSourceAPI, ExpiresAt: &future},
+		{Email: "event@example.com", Reason: model.SuppressionReasonBounce, Source: model.SuppressionSourceEvent},
+		{Email: "removed@example.com", Reason: model.SuppressionReasonBounce, Source: model.SuppressionSourceEvent},
+	} {
+		if err := model.SaveSuppression(db, s); err != nil {
+			t.Fatalf("SaveSuppression() 에러 = %v", err)
+		}
+	}
+	if err := model.RemoveSuppression(db, "removed@example.com"); err != nil {
+		t.Fatalf("RemoveSuppression() 에러 = %v", err)
+	}
+
+	ses := &fakeSuppressionList{}
+	s := &suppressionSyncer{db: db, ses: ses}
+	if err := s.sync(context.Background(), now); err != nil {
+		t.Fatalf("sync() 에러 = %v", err)
+	}
+
+	// 만료 시각이 있는 항목과 이벤트로 등록된 항목은 추가하지 않음 (MANUAL은 BOUNCE로 추가)
+	wantPut := map[string]string{"manual@example.com": "BOUNCE", "complaint@example.com": "COMPLAINT"}
+	if len(ses.put) != len(wantPut) {
+		t.Errorf("추가된 주소 = %v, 예상 = %v", ses.put, wantPut)
+	}
+	for email, reason := range wantPut {
+		if ses.put[email] != reason {
+			t.Errorf("%s 추가 사유 = %q, 예상 = %q", email, ses.put[email], reason)
+		}
+	}
+	if len(ses.deleted) != 1 || ses.deleted[0] != "removed@example.com" {
+		t.Errorf("삭제된 주소 = %v, 예상 = [removed@example.com]", ses.deleted)
+	}
+
+	// 반영이 끝난 항목은 다음 동기화에서 다시 보내지 않음
+	ses.put, ses.deleted = nil, nil
+	if err := s.sync(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("sync() 에러 = %v", err)
+	}
+	if len(ses.put) != 0 || len(ses.deleted) != 0 {
+		t.Errorf("재동기화 추가/삭제 = %v/%v, 예상 = 없음", ses.put, ses.deleted)
+	}
+}
+
+// TestSuppressionSyncPushConcurrentRemoval SES에 추가하는 동안 해제된 항목은 반영 완료로 기록하지 않고 다음 동기화에서 삭제
+func TestSuppressionSyncPushConcurrentRemoval(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Now().UTC()
+	sup := &model.Suppression{Email: "user@example.com", Reason: model.SuppressionReasonManual, Source: model.SuppressionSourceAPI}
+	if err := model.SaveSuppression(db, sup); err != nil {
+		t.Fatalf("SaveSuppression() 에러 = %v", err)
+	}
+
+	ses := &fakeSuppressionList{onPut: func(email string) {
+		if err := model.RemoveSuppression(db, email); err != nil {
+			t.Fatalf("RemoveSuppression() 에러 = %v", err)
+		}
+	}}
+	s := &suppressionSyncer{db: db, ses: ses}
+	if err := s.sync(context.Background(), now); err != nil {
+		t.Fatalf("sync() 에러 = %v", err)
+	}
+	if len(ses.put) != 1 || len(ses.deleted) != 0 {
+		t.Fatalf("추가/삭제 = %v/%v, 예상 = 1건 추가", ses.put, ses.deleted)
+	}
+
+	ses.onPut = nil
+	if err := s.sync(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("sync() 에러 = %v", err)
+	}
+	if len(ses.deleted) != 1 || ses.deleted[0] != "user@example.com" {
+		t.Errorf("삭제된 주소 = %v, 예상 = [user@example.com] (해제가 SES에 반영되어야 함)", ses.deleted)
+	}
+}
+
+// TestSuppressionSyncPull SES 목록을 페이지 단위로 가져오고 증분 조회 시작 시각을 기록하는지 테스트
+func TestSuppressionSyncPull(t *testing.T) {
+	db := newTestDB(t)
+	first := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ses := &fakeSuppressionList{entries: []aws.SuppressedDestination{
+		{Email: "a@example.com", Reason: "BOUNCE", LastUpdateTime: first.Add(-48 * time.Hour)},
+		{Email: "b@example.com", Reason: "COMPLAINT", LastUpdateTime: first.Add(-24 * time.Hour)},
+		{Email: "C@example.com", Reason: "BOUNCE", LastUpdateTime: first.Add(-time.Hour)},
+	}}
+	s := &suppressionSyncer{db: db, ses: ses}
+
+	// 첫 동기화는 전체 조회
+	if err := s.sync(context.Background(), first); err != nil {
+		t.Fatalf("sync() 에러 = %v", err)
+	}
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		sup, err := model.FindSuppression(db, email)
+		if err != nil {
+			t.Fatalf("FindSuppression(%s) 에러 = %v", email, err)
+		}
+		if sup.Source != model.SuppressionSourceSES {
+			t.Errorf("%s Source = %s, 예상 = ses", email, sup.Source)
+		}
+	}
+
+	// 이후 동기화는 마지막 동기화 시각부터 조회
+	ses.entries = append(ses.entries, aws.SuppressedDestination{Email: "d@example.com", Reason: "BOUNCE", LastUpdateTime: first.Add(time.Hour)})
+	second := first.Add(2 * time.Hour)
+	if err := s.pull(context.Background(), second); err != nil {
+		t.Fatalf("pull() 에러 = %v", err)
+	}
+	if len(ses.since) != 2 || !ses.since[0].IsZero() || !ses.since[1].Equal(first.Add(-sesSuppressionOverlap)) {
+		t.Errorf("StartDate = %v, 예상 = [전체, %s]", ses.since, first.Add(-sesSuppressionOverlap))
+	}
+	if _, err := model.FindSuppression(db, "d@example.com"); err != nil {
+		t.Errorf("증분 조회 항목이 추가되지 않음: %v", err)
+	}
+
+	// 전체 조회 모드는 항상 StartDate 없이 조회
+	s.full = true
+	if err := s.pull(context.Background(), second); err != nil {
+		t.Fatalf("pull() 에러 = %v", err)
+	}
+	if last := ses.since[len(ses.since)-1]; !last.IsZero() {
+		t.Errorf("전체 조회 StartDate = %s, 예상 = 없음", last)
+	}
+}
+
+// TestSuppressionSyncFailureKeepsCursor 조회 실패 시 증분 조회 시작 시각을 유지하고 실패 사유를 기록
+func TestSuppressionSyncFailureKeepsCursor(t *testing.T) {
+	db := newTestDB(t)
+	first := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ses := &fakeSuppressionList{}
+	s := &suppressionSyncer{db: db, ses: ses}
+	if err := s.sync(context.Background(), first); err != nil {
+		t.Fatalf("sync() 에러 = %v", err)
+	}
+
+	ses.listErr = errors.New("throttled")
+	if err := s.sync(context.Background(), first.Add(time.Hour)); err == nil {
+		t.Fatal("sync() 에러를 예상했지만 nil이 반환됨")
+	}
+
+	state, err := model.GetSyncState(db, sesSuppressionSyncName)
+	if err != nil {
+		t.Fatalf("GetSyncState() 에러 = %v", err)
+	}
+	if state.Cursor == nil || !state.Cursor.Equal(first) {
+		t.Errorf("Cursor = %v, 예상 = %s", state.Cursor, first)
+	}
+	if state.LastError != "throttled" || state.LastRunAt == nil || !state.LastRunAt.Equal(first.Add(time.Hour)) {
+		t.Errorf("LastError/LastRunAt = %q/%v", state.LastError, state.LastRunAt)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// TestSuppressFromEvent 영구 반송과 수신 거부 신고만 수신 거부 목록에 자동 등록
+func TestSuppressFromEvent(t *testing.T) {
+	tests := []struct {
+		name       string // 테스트 케이스 이름
+		body       string // SES 이벤트 유형별 본문
+		wantReason string // 예상 수신 거부 사유 (빈 값이면 등록되지 않음)
+	}{
+		{
+			name:       "영구 반송",
+			body:       `"notificationType":"Bounce","bounce":{"bounceType":"Permanent","bounceSubType":"NoEmail","bouncedRecipients":[{"emailAddress":"User@Example.com","diagnosticCode":"smtp; 550"}]}`,
+			wantReason: model.SuppressionReasonBounce,
+		},
+		{
+			name:       "수신 거부 신고",
+			body:       `"eventType":"Complaint","complaint":{"complaintFeedbackType":"abuse","complainedRecipients":[{"emailAddress":"user@example.com"}]}`,
+			wantReason: model.SuppressionReasonComplaint,
+		},
+		{
+			name: "일시 반송은 등록하지 않음",
+			body: `"notificationType":"Bounce","bounce":{"bounceType":"Transient","bouncedRecipients":[{"emailAddress":"user@example.com"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			req := createTestRequest(t, db, "user@example.com")
+			setMessageID(t, db, req, "m-1")
+			raw := fmt.Sprintf(`{"mail":{"messageId":"m-1","headers":[{"name":"X-Request-ID","value":"%d"}]},%s}`, req.ID, tt.body)
+
+			result, err := recordSESEvent(db, "sns-1", raw)
+			if err != nil {
+				t.Fatalf("recordSESEvent() 에러 = %v", err)
+			}
+
+			s, err := model.FindSuppression(db, "user@example.com")
+			if tt.wantReason == "" {
+				if !errors.Is(err, model.ErrSuppressionNotFound) {
+					t.Errorf("FindSuppression() = %+v, %v, 예상 = 등록되지 않음", s, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindSuppression() 에러 = %v", err)
+			}
+			if s.Reason != tt.wantReason || s.Source != model.SuppressionSourceEvent {
+				t.Errorf("Reason/Source = %s/%s, 예상 = %s/event", s.Reason, s.Source, tt.wantReason)
+			}
+			if s.ResultId == nil || *s.ResultId != result.ID || s.MessageId != "m-1" {
+				t.Errorf("원인 이벤트 = %v/%s, 예상 = %d/m-1", s.ResultId, s.MessageId, result.ID)
+			}
+		})
+	}
+}
+
+// TestDropSuppressed 수신 거부 목록에 있는 요청은 발송 대기열에서 제외하고 상태 변경
+func TestDropSuppressed(t *testing.T) {
+	db := newTestDB(t)
+	blocked := createTestRequest(t, db, "Blocked@example.com")
+	allowed := createTestRequest(t, db, "allowed@example.com")
+	if err := model.SaveSuppression(db, &model.Suppression{
+		Email: "blocked@example.com", Reason: model.SuppressionReasonComplaint, Source: model.SuppressionSourceAPI,
+	}); err != nil {
+		t.Fatalf("SaveSuppression() 에러 = %v", err)
+	}
+
+	kept, err := dropSuppressed(db, []*model.Request{blocked, allowed}, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("dropSuppressed() 에러 = %v", err)
+	}
+	if len(kept) != 1 || kept[0].ID != allowed.ID {
+		t.Fatalf("남은 요청 = %v, 예상 = [%d]", kept, allowed.ID)
+	}
+
+	saved := loadRequest(t, db, blocked.ID)
+	if saved.Status != model.EmailMsgStatusSuppressed || saved.Error != "suppressed: COMPLAINT" || saved.LockedUntil != nil {
+		t.Errorf("수신 거부 요청 = status %d, error %q, lockedUntil %v", saved.Status, saved.Error, saved.LockedUntil)
+	}
+}
+
+// TestSendNowSuppressed 즉시 발송도 수신 거부 주소는 발송하지 않음
+func TestSendNowSuppressed(t *testing.T) {
+	db := newTestDB(t)
+	content, req := newSendNowRequest()
+	if err := model.SaveSuppression(db, &model.Suppression{
+		Email: req.To, Reason: model.SuppressionReasonBounce, Source: model.SuppressionSourceEvent,
+	}); err != nil {
+		t.Fatalf("SaveSuppression() 에러 = %v", err)
+	}
+
+	m := &fakeMailer{}
+	result, err := sendNow(context.Background(), db, m, newRateController(100, nil, 0.9, time.Minute), semaphore.NewWeighted(1), content, req)
+	if !errors.Is(err, ErrRecipientSuppressed) {
+		t.Fatalf("sendNow() 에러 = %v, 예상 = %v", err, ErrRecipientSuppressed)
+	}
+	if result.Status != "suppressed" || len(m.sent) != 0 {
+		t.Errorf("결과 = %+v, 발송 수 = %d, 예상 = suppressed, 0", result, len(m.sent))
+	}
+	if saved := loadRequest(t, db, result.RequestID); saved.Status != model.EmailMsgStatusSuppressed {
+		t.Errorf("저장된 Status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusSuppressed)
+	}
+}
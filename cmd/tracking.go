@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/tracking"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrLinkNotFound 클릭 추적 링크를 찾을 수 없는 경우
+var ErrLinkNotFound = errors.New("tracked link not found")
+
+var (
+	trackingSignerInstance *tracking.Signer
+	trackingSignerOnce     sync.Once
+	// trackingKeyTemporary TRACKING_KEYS 없이 임시 키로 서명하는지 여부 (재시작하면 이전 서명이 검증되지 않음)
+	trackingKeyTemporary bool
+)
+
+// getTrackingSigner 추적 URL 서명기 반환 (싱글톤)
+// TRACKING_KEYS는 쉼표로 구분하며 첫 번째 키로 서명하고 모든 키로 검증
+func getTrackingSigner() *tracking.Signer {
+	trackingSignerOnce.Do(func() {
+		signer, err := tracking.NewSigner(strings.Split(config.GetEnv("TRACKING_KEYS", ""), ",")...)
+		if err != nil {
+			// 키가 없으면 임시 키 사용 (재시작 전에 발송된 추적 URL은 검증되지 않음, 수신 거부 헤더는 생략)
+			log.Println("TRACKING_KEYS is not set, using a temporary key; tracking links will not verify after restart and List-Unsubscribe headers are disabled")
+			key := make([]byte, 32)
+			rand.Read(key)
+			signer, _ = tracking.NewSigner(base64.RawURLEncoding.EncodeToString(key))
+			trackingKeyTemporary = true
+		}
+		trackingSignerInstance = signer
+	})
+	return trackingSignerInstance
+}
+
+// VerifyTracking 추적 URL 서명 검증
+func VerifyTracking(event string, requestID uint, signature string) bool {
+	return getTrackingSigner().Verify(event, requestID, signature)
+}
+
+// openEvent 열람 이벤트 원시 데이터
+type openEvent struct {
+	UserAgent string `json:"userAgent"`
+	IP        string `json:"ip"`
+	tracking.Client
+	First bool `json:"first"` // 요청의 첫 열람 여부 (분류와 무관)
+}
+
+// RecordOpen 열람 이벤트 기록 (User-Agent, IP, 프록시/봇 분류, 첫 열람 여부 포함)
+// 같은 클라이언트가 OPEN_DEDUPE_WINDOW 안에 다시 요청하면 중복으로 보고 기록하지 않음
+func RecordOpen(requestID uint, userAgent, ip string) error {
+	window := config.GetEnvAsDuration("OPEN_DEDUPE_WINDOW", time.Minute)
+	return recordOpen(config.GetDB(), requestID, userAgent, ip, window, time.Now().UTC())
+}
+
+// recordOpen 열람 이벤트 기록 (의존성 주입 버전)
+func recordOpen(db *gorm.DB, requestID uint, userAgent, ip string, window time.Duration, now time.Time) error {
+	// SES 열람 이벤트는 분류 정보가 없으므로 중복 제거와 첫 열람 판단에서 제외
+	opens := db.Model(&model.Result{}).Scopes(model.TrackingEvents).Where("request_id = ? AND status = ?", requestID, "Open")
+
+	var dup int64
+	err := opens.Session(&gorm.Session{}).
+		Where("created_at >= ?", now.Add(-window)).
+		Where("json_extract(raw, '$.userAgent') = ? AND json_extract(raw, '$.ip') = ?", userAgent, ip).
+		Count(&dup).Error
+	if err != nil {
+		return fmt.Errorf("failed to check duplicate open: %w", err)
+	}
+	if dup > 0 {
+		return nil
+	}
+
+	var prior int64
+	if err := opens.Session(&gorm.Session{}).Count(&prior).Error; err != nil {
+		return fmt.Errorf("failed to count opens: %w", err)
+	}
+
+	raw, _ := json.Marshal(openEvent{
+		UserAgent: userAgent,
+		IP:        ip,
+		Client:    tracking.Classify(userAgent, ip),
+		First:     prior == 0,
+	})
+	result := &model.Result{RequestId: requestID, Status: "Open", Raw: string(raw)}
+	result.CreatedAt = now
+	if err := db.Create(result).Error; err != nil {
+		return fmt.Errorf("failed to create open event: %w", err)
+	}
+	return nil
+}
+
+// openTrackingURL 서명된 오픈 추적 픽셀 URL
+func openTrackingURL(serverHost string, requestID uint) string {
+	return fmt.Sprintf("%s/v1/events/open?requestId=%d&sig=%s",
+		serverHost, requestID, getTrackingSigner().Sign(tracking.EventOpen, requestID))
+}
+
+var (
+	anchorTagPattern = regexp.MustCompile(`(?is)<a\s[^>]*>`)
+	hrefAttrPattern  = regexp.MustCompile(`(?is)(\shref\s*=\s*)("[^"]*"|'[^']*')`)
+	noTrackPattern   = regexp.MustCompile(`(?i)\sdata-notrack(\s|=|/?>)`)
+)
+
+// trackableLink 클릭 추적 대상 링크 여부 (http, https 링크만 추적)
+func trackableLink(tag, href string) bool {
+	if noTrackPattern.MatchString(tag) {
+		return false
+	}
+	lower := strings.ToLower(strings.TrimSpace(href))
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// eachTrackedLink 본문의 추적 대상 링크마다 순서대로 fn 호출 (fn 반환값으로 href 교체)
+// data-notrack 속성이 있거나 mailto:, tel:, # 등 http(s)가 아닌 링크는 제외
+func eachTrackedLink(body string, fn func(index int, url string) string) string {
+	index := 0
+	return anchorTagPattern.ReplaceAllStringFunc(body, func(tag string) string {
+		m := hrefAttrPattern.FindStringSubmatchIndex(tag)
+		if m == nil {
+			return tag
+		}
+		quoted := tag[m[4]:m[5]]
+		href := html.UnescapeString(quoted[1 : len(quoted)-1])
+		if !trackableLink(tag, href) {
+			return tag
+		}
+		replaced := fn(index, strings.TrimSpace(href))
+		index++
+		return tag[:m[4]] + `"` + html.EscapeString(replaced) + `"` + tag[m[5]:]
+	})
+}
+
+// rewriteLinks 본문의 추적 대상 링크를 서명된 클릭 추적 URL로 교체
+func rewriteLinks(body, serverHost string, requestID uint) string {
+	sig := getTrackingSigner().Sign(tracking.EventClick, requestID)
+	return eachTrackedLink(body, func(index int, _ string) string {
+		return fmt.Sprintf("%s/v1/events/click?requestId=%d&link=%d&sig=%s", serverHost, requestID, index, sig)
+	})
+}
+
+// RecordClick 클릭 추적 링크의 원래 URL 조회 후 클릭 이벤트 기록
+// 서명이 유효하지 않으면 링크를 조회하지 않고 ErrLinkNotFound 반환 (요청 ID와 링크 번호로 개인화 URL 수집 방지)
+// 이벤트 저장에 실패해도 수신자가 이동할 수 있도록 URL은 반환
+func RecordClick(requestID uint, index int, signature string) (string, error) {
+	return recordClick(config.GetDB(), getTrackingSigner(), requestID, index, signature)
+}
+
+// recordClick 클릭 이벤트 기록 (의존성 주입 버전)
+func recordClick(db *gorm.DB, signer *tracking.Signer, requestID uint, index int, signature string) (string, error) {
+	if !signer.Verify(tracking.EventClick, requestID, signature) {
+		return "", ErrLinkNotFound
+	}
+	url, err := trackedLink(db, requestID, index)
+	if err != nil {
+		return "", err
+	}
+
+	raw, _ := json.Marshal(map[string]interface{}{"url": url, "link": index})
+	result := &model.Result{RequestId: requestID, Status: "Click", Raw: string(raw)}
+	if err := db.Create(result).Error; err != nil {
+		log.Printf("Failed to create click event for requestId %d: %v", requestID, err)
+	}
+	return url, nil
+}
+
+// trackedLink 발송 시점과 같은 방식으로 본문을 렌더링하여 index번째 추적 링크 조회
+// 링크 URL을 추적 URL에 싣지 않으므로 임의 URL로의 리다이렉트에 악용될 수 없음
+func trackedLink(db *gorm.DB, requestID uint, index int) (string, error) {
+	if index < 0 {
+		return "", ErrLinkNotFound
+	}
+	var req model.Request
+	if err := db.Preload("Content").First(&req, requestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrLinkNotFound
+		}
+		return "", fmt.Errorf("failed to find request: %w", err)
+	}
+	if req.Content.NoClickTracking {
+		return "", ErrLinkNotFound
+	}
+
+	rendered, err := renderContent(&req.Content, req.Vars)
+	if err != nil {
+		return "", fmt.Errorf("failed to render content: %w", err)
+	}
+	var url string
+	eachTrackedLink(rendered.HTML, func(i int, href string) string {
+		if i == index {
+			url = href
+		}
+		return href
+	})
+	if url == "" {
+		return "", ErrLinkNotFound
+	}
+	return url, nil
+}
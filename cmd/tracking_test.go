@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/tracking"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRewriteLinks 클릭 추적 링크 교체 테스트
+func TestRewriteLinks(t *testing.T) {
+	tests := []struct {
+		name string // 테스트 케이스 이름
+		body string // 원본 본문
+		want string // 예상 본문
+	}{
+		{
+			name: "http 링크 교체",
+			body: `<a href="https://example.com/a">A</a><a class="btn" href='http://example.com/b'>B</a>`,
+			want: `<a href="https://t.example/v1/events/click?requestId=7&amp;link=0&amp;sig=SIG">A</a>` +
+				`<a class="btn" href="https://t.example/v1/events/click?requestId=7&amp;link=1&amp;sig=SIG">B</a>`,
+		},
+		{
+			name: "제외 대상 링크는 번호를 매기지 않음",
+			body: `<a href="mailto:a@example.com">M</a><a href="tel:010">T</a><a href="#top">H</a>` +
+				`<a data-notrack href="https://example.com/x">X</a><a href="https://example.com/y">Y</a>`,
+			want: `<a href="mailto:a@example.com">M</a><a href="tel:010">T</a><a href="#top">H</a>` +
+				`<a data-notrack href="https://example.com/x">X</a><a href="https://t.example/v1/events/click?requestId=7&amp;link=0&amp;sig=SIG">Y</a>`,
+		},
+		{
+			name: "href 없는 앵커",
+			body: `<a name="top">Top</a>`,
+			want: `<a name="top">Top</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := strings.ReplaceAll(tt.want, "SIG", getTrackingSigner().Sign(tracking.EventClick, 7))
+			if got := rewriteLinks(tt.body, "https://t.example", 7); got != want {
+				t.Errorf("rewriteLinks() = %q, 예상 = %q", got, want)
+			}
+		})
+	}
+}
+
+// TestRecordClick 클릭 링크 조회 및 이벤트 기록 테스트
+func TestRecordClick(t *testing.T) {
+	db := newTestDB(t)
+
+	content := &model.Content{
+		Subject: "제목",
+		Content: `<a href="mailto:help@example.com">문의</a><a href="https://example.com/u?id={{.id}}&amp;ref=mail">확인</a>`,
+	}
+	if err := db.Create(content).Error; err != nil {
+		t.Fatalf("Content 생성 실패: %v", err)
+	}
+	now := time.Now().UTC()
+	req := &model.Request{To: "user@example.com", ContentId: content.ID, ScheduledAt: &now, Vars: `{"id":"42"}`}
+	if err := db.Create(req).Error; err != nil {
+		t.Fatalf("Request 생성 실패: %v", err)
+	}
+
+	signer, _ := tracking.NewSigner("test-key")
+	sig := signer.Sign(tracking.EventClick, req.ID)
+
+	// 서명이 없거나 다른 요청, 다른 이벤트의 서명이면 링크 URL을 반환하지 않고 기록하지 않음
+	for _, bad := range []string{"", signer.Sign(tracking.EventClick, req.ID+1), signer.Sign(tracking.EventOpen, req.ID)} {
+		if url, err := recordClick(db, signer, req.ID, 0, bad); !errors.Is(err, ErrLinkNotFound) || url != "" {
+			t.Errorf("잘못된 서명 recordClick(%q) = %q, %v, 예상 에러 = %v", bad, url, err, ErrLinkNotFound)
+		}
+	}
+	var cnt int64
+	db.Model(&model.Result{}).Where("request_id = ?", req.ID).Count(&cnt)
+	if cnt != 0 {
+		t.Errorf("잘못된 서명 클릭 이벤트 수 = %d, 예상 = 0", cnt)
+	}
+
+	url, err := recordClick(db, signer, req.ID, 0, sig)
+	if err != nil {
+		t.Fatalf("recordClick() 에러 = %v", err)
+	}
+	if url != "https://example.com/u?id=42&ref=mail" {
+		t.Errorf("recordClick() URL = %q, 예상 = %q", url, "https://example.com/u?id=42&ref=mail")
+	}
+
+	var result model.Result
+	if err := db.Where("request_id = ?", req.ID).First(&result).Error; err != nil {
+		t.Fatalf("클릭 이벤트 조회 실패: %v", err)
+	}
+	if result.Status != "Click" || !strings.Contains(result.Raw, "https://example.com/u?id=42") {
+		t.Errorf("클릭 이벤트 = %+v, 상태 Click과 원래 URL이 기록되어야 함", result)
+	}
+
+	if _, err := recordClick(db, signer, req.ID, 1, sig); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("없는 링크 번호 에러 = %v, 예상 = %v", err, ErrLinkNotFound)
+	}
+	if _, err := recordClick(db, signer, req.ID+100, 0, sig); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("없는 요청 에러 = %v, 예상 = %v", err, ErrLinkNotFound)
+	}
+
+	// 클릭 추적을 끈 메시지는 추적 링크가 없으므로 조회하지 않음
+	if err := db.Model(content).Update("no_click_tracking", true).Error; err != nil {
+		t.Fatalf("Content 수정 실패: %v", err)
+	}
+	if _, err := recordClick(db, signer, req.ID, 0, sig); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("클릭 추적 해제 메시지 에러 = %v, 예상 = %v", err, ErrLinkNotFound)
+	}
+}
+
+// TestRecordOpen 열람 이벤트 분류, 첫 열람 여부, 중복 제거 테스트
+func TestRecordOpen(t *testing.T) {
+	db := newTestDB(t)
+	req := createTestRequest(t, db, "user@example.com")
+
+	const browser = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0"
+	now := time.Now().UTC()
+
+	// SES가 먼저 보낸 열람 이벤트는 첫 열람으로 보지 않음
+	ses := &model.Result{RequestId: req.ID, Status: "Open", Raw: "{}", SnsMessageId: nullableString("sns-open")}
+	if err := db.Create(ses).Error; err != nil {
+		t.Fatalf("SES 열람 이벤트 생성 실패: %v", err)
+	}
+
+	hits := []struct {
+		userAgent string        // User-Agent
+		ip        string        // 클라이언트 IP
+		offset    time.Duration // 기준 시각 대비 요청 시각
+	}{
+		{"Mozilla/5.0", "17.58.1.1", 0},       // Apple MPP 사전 로딩
+		{browser, "203.0.113.1", time.Second}, // 수신자 열람
+		{browser, "203.0.113.1", 2 * time.Second},
+		{browser, "203.0.113.1", 2 * time.Minute}, // 중복 제거 시간 이후 재열람
+	}
+	for _, h := range hits {
+		if err := recordOpen(db, req.ID, h.userAgent, h.ip, time.Minute, now.Add(h.offset)); err != nil {
+			t.Fatalf("recordOpen() 에러 = %v", err)
+		}
+	}
+
+	var results []model.Result
+	if err := db.Scopes(model.TrackingEvents).Where("request_id = ?", req.ID).Order("id").Find(&results).Error; err != nil {
+		t.Fatalf("열람 이벤트 조회 실패: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("열람 이벤트 수 = %d, 예상 = 3 (중복 1건 제외)", len(results))
+	}
+
+	want := []struct {
+		kind  string // 예상 분류
+		first bool   // 예상 첫 열람 여부
+	}{
+		{tracking.KindProxy, true},
+		{tracking.KindHuman, false},
+		{tracking.KindHuman, false},
+	}
+	for i, r := range results {
+		var ev openEvent
+		if err := json.Unmarshal([]byte(r.Raw), &ev); err != nil {
+			t.Fatalf("열람 이벤트 파싱 실패: %v", err)
+		}
+		if ev.Kind != want[i].kind || ev.First != want[i].first {
+			t.Errorf("%d번째 열람 = %+v, 예상 분류 = %s, 첫 열람 = %v", i, ev, want[i].kind, want[i].first)
+		}
+		if ev.UserAgent == "" || ev.IP == "" {
+			t.Errorf("%d번째 열람에 User-Agent 또는 IP가 없음: %s", i, r.Raw)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/model"
+	"aws-ses-sender-go/pkg/tracking"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRecipientUnsubscribed 수신자가 수신 거부한 주소인 경우
+	ErrRecipientUnsubscribed = errors.New("recipient has unsubscribed")
+	// ErrUnsubscribeNotFound 수신 거부 URL의 발송 요청을 찾을 수 없는 경우
+	ErrUnsubscribeNotFound = errors.New("unsubscribe request not found")
+	// ErrInvalidUnsubscribeScope 지원하지 않는 수신 거부 범위인 경우
+	ErrInvalidUnsubscribeScope = errors.New("invalid unsubscribe scope")
+)
+
+// unsubscribeURL 서명된 수신 거부 URL (List-Unsubscribe 헤더와 안내 페이지에 사용)
+// 임시 키로 서명한 URL은 재시작 후 검증되지 않아 원클릭 수신 거부가 동작하지 않으므로 빈 문자열 반환 (헤더 생략)
+func unsubscribeURL(serverHost string, requestID uint) string {
+	signer := getTrackingSigner()
+	if trackingKeyTemporary {
+		return ""
+	}
+	return fmt.Sprintf("%s/v1/unsubscribe?requestId=%d&sig=%s",
+		serverHost, requestID, signer.Sign(tracking.EventUnsubscribe, requestID))
+}
+
+// VerifyUnsubscribe 수신 거부 URL 서명 검증
+func VerifyUnsubscribe(requestID uint, signature string) bool {
+	return getTrackingSigner().Verify(tracking.EventUnsubscribe, requestID, signature)
+}
+
+// UnsubscribeTarget 수신 거부 URL의 발송 요청 조회 (안내 페이지 표시용)
+func UnsubscribeTarget(requestID uint) (*model.Request, error) {
+	return unsubscribeTarget(config.GetDB(), requestID)
+}
+
+// unsubscribeTarget 수신 거부 대상 발송 요청 조회 (의존성 주입 버전)
+func unsubscribeTarget(db *gorm.DB, requestID uint) (*model.Request, error) {
+	var req model.Request
+	if err := db.First(&req, requestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUnsubscribeNotFound
+		}
+		return nil, fmt.Errorf("failed to find request: %w", err)
+	}
+	return &req, nil
+}
+
+// Unsubscribe 발송 요청의 수신자를 수신 거부 처리
+// scope가 비어 있으면 UNSUBSCRIBE_SCOPE (기본값 topic), 토픽이 없는 요청은 모든 토픽 수신 거부
+func Unsubscribe(requestID uint, scope, method string) (*model.Unsubscribe, error) {
+	if scope == "" {
+		scope = config.GetEnv("UNSUBSCRIBE_SCOPE", model.UnsubscribeScopeTopic)
+	}
+	return unsubscribe(config.GetDB(), requestID, scope, method)
+}
+
+// unsubscribe 수신 거부 처리 (의존성 주입 버전)
+func unsubscribe(db *gorm.DB, requestID uint, scope, method string) (*model.Unsubscribe, error) {
+	scope = strings.ToLower(strings.TrimSpace(scope))
+	if scope != model.UnsubscribeScopeTopic && scope != model.UnsubscribeScopeGlobal {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidUnsubscribeScope, scope)
+	}
+
+	req, err := unsubscribeTarget(db, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &model.Unsubscribe{Email: req.To, RequestId: &req.ID, Method: method}
+	if scope == model.UnsubscribeScopeTopic {
+		u.TopicId = req.TopicId
+	}
+	if err := model.SaveUnsubscribe(db, u); err != nil {
+		return nil, err
+	}
+	log.Printf("Unsubscribed %s (scope=%s, topicId=%s, requestId=%d, method=%s)", u.Email, u.Scope(), u.TopicId, requestID, method)
+	return u, nil
+}
+
+// dropUnsubscribed 수신자가 수신 거부했거나 카테고리 구독을 해지한 요청을 수신 거부 상태로 전환하고 나머지 요청만 반환
+// 카테고리 없이 생성된 요청은 생성 이후 토픽이 카테고리에 추가되었을 수 있으므로 가져갈 때 토픽의 카테고리로 확인
+func dropUnsubscribed(db *gorm.DB, reqs []*model.Request) ([]*model.Request, error) {
+	emails := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		emails = append(emails, req.To)
+	}
+	unsubscribed, err := model.UnsubscribedTopics(db, emails)
+	if err != nil {
+		return reqs, err
+	}
+	optedOut, err := model.OptedOutCategories(db, emails)
+	if err != nil {
+		return reqs, err
+	}
+	if len(unsubscribed) == 0 && len(optedOut) == 0 {
+		return reqs, nil
+	}
+	var topicCategories map[string]string
+	if len(optedOut) > 0 {
+		if topicCategories, err = model.TopicCategories(db); err != nil {
+			return reqs, err
+		}
+	}
+
+	kept := reqs[:0]
+	for _, req := range reqs {
+		email := model.NormalizeEmail(req.To)
+		category := req.Category
+		if category == "" {
+			category = topicCategories[req.TopicId]
+		}
+		reason := unsubscribedReason(req, category, unsubscribed[email], optedOut[email])
+		if reason == "" {
+			kept = append(kept, req)
+			continue
+		}
+		if err := markSkipped(db, req, model.EmailMsgStatusUnsubscribed, reason); err != nil {
+			log.Printf("Failed to mark request as unsubscribed (RequestID=%d): %v", req.ID, err)
+		}
+	}
+	return kept, nil
+}
+
+// unsubscribedReason 수신 거부로 발송하지 않을 요청의 에러 메시지 (발송 가능하면 빈 문자열)
+// 모든 토픽 수신 거부, 토픽 수신 거부, 요청이 속한 카테고리 구독 해지 순으로 확인
+func unsubscribedReason(req *model.Request, category string, topics, categories map[string]bool) string {
+	switch {
+	case topics[""]:
+		return "unsubscribed: " + model.UnsubscribeScopeGlobal
+	case model.IsUnsubscribed(topics, req.TopicId):
+		return "unsubscribed: " + model.UnsubscribeScopeTopic
+	case category != "" && categories[category]:
+		return "unsubscribed: category " + category
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"aws-ses-sender-go/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"gorm.io/gorm"
+)
+
+// createTopicRequest 토픽이 지정된 테스트용 발송 요청 생성
+func createTopicRequest(t *testing.T, db *gorm.DB, to, topicId string) *model.Request {
+	t.Helper()
+	req := createTestRequest(t, db, to)
+	if err := db.Model(req).Update("topic_id", topicId).Error; err != nil {
+		t.Fatalf("TopicId 설정 실패: %v", err)
+	}
+	req.TopicId = topicId
+	return req
+}
+
+// TestUnsubscribe 수신 거부 범위별 저장 테스트
+func TestUnsubscribe(t *testing.T) {
+	tests := []struct {
+		name      string // 테스트 케이스 이름
+		topicId   string // 발송 요청 토픽
+		scope     string // 수신 거부 범위
+		wantTopic string // 예상 저장 토픽 (빈 값이면 모든 토픽)
+		wantErr   error  // 예상 에러
+	}{
+		{"토픽 수신 거부", "newsletter", "topic", "newsletter", nil},
+		{"전체 수신 거부", "newsletter", "GLOBAL", "", nil},
+		{"토픽 없는 요청은 전체 수신 거부", "", "topic", "", nil},
+		{"지원하지 않는 범위", "newsletter", "forever", "", ErrInvalidUnsubscribeScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			req := createTopicRequest(t, db, "User@example.com", tt.topicId)
+
+			u, err := unsubscribe(db, req.ID, tt.scope, model.UnsubscribeMethodOneClick)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("unsubscribe() 에러 = %v, 예상 = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unsubscribe() 에러 = %v", err)
+			}
+			if u.Email != "user@example.com" || u.TopicId != tt.wantTopic || u.RequestId == nil || *u.RequestId != req.ID {
+				t.Errorf("저장된 수신 거부 = %+v, 예상 토픽 = %q", u, tt.wantTopic)
+			}
+		})
+	}
+
+	t.Run("없는 요청", func(t *testing.T) {
+		db := newTestDB(t)
+		if _, err := unsubscribe(db, 999, "topic", model.UnsubscribeMethodPage); !errors.Is(err, ErrUnsubscribeNotFound) {
+			t.Errorf("unsubscribe() 에러 = %v, 예상 = %v", err, ErrUnsubscribeNotFound)
+		}
+	})
+}
+
+// TestDropUnsubscribed 수신 거부한 토픽과 전체 수신 거부 주소만 발송 대기열에서 제외
+func TestDropUnsubscribed(t *testing.T) {
+	db := newTestDB(t)
+	for _, u := range []*model.Unsubscribe{
+		{Email: "topic@example.com", TopicId: "newsletter", Method: model.UnsubscribeMethodOneClick},
+		{Email: "all@example.com", Method: model.UnsubscribeMethodPage},
+	} {
+		if err := model.SaveUnsubscribe(db, u); err != nil {
+			t.Fatalf("SaveUnsubscribe() 에러 = %v", err)
+		}
+	}
+
+	sameTopic := createTopicRequest(t, db, "Topic@example.com", "newsletter")
+	otherTopic := createTopicRequest(t, db, "topic@example.com", "billing")
+	global := createTopicRequest(t, db, "all@example.com", "billing")
+	allowed := createTopicRequest(t, db, "other@example.com", "newsletter")
+
+	kept, err := dropUnsubscribed(db, []*model.Request{sameTopic, otherTopic, global, allowed})
+	if err != nil {
+		t.Fatalf("dropUnsubscribed() 에러 = %v", err)
+	}
+	if len(kept) != 2 || kept[0].ID != otherTopic.ID || kept[1].ID != allowed.ID {
+		t.Fatalf("남은 요청 수 = %d, 예상 = [%d %d]", len(kept), otherTopic.ID, allowed.ID)
+	}
+
+	for _, tc := range []struct {
+		id        uint
+		wantError string
+	}{
+		{sameTopic.ID, "unsubscribed: topic"},
+		{global.ID, "unsubscribed: global"},
+	} {
+		saved := loadRequest(t, db, tc.id)
+		if saved.Status != model.EmailMsgStatusUnsubscribed || saved.Error != tc.wantError || saved.LockedUntil != nil {
+			t.Errorf("RequestID=%d status %d, error %q, lockedUntil %v", tc.id, saved.Status, saved.Error, saved.LockedUntil)
+		}
+	}
+}
+
+// TestSendNowUnsubscribed 즉시 발송도 수신 거부한 주소는 발송하지 않음
+func TestSendNowUnsubscribed(t *testing.T) {
+	db := newTestDB(t)
+	content, req := newSendNowRequest()
+	req.TopicId = "otp"
+	if err := model.SaveUnsubscribe(db, &model.Unsubscribe{Email: req.To, TopicId: "otp", Method: model.UnsubscribeMethodOneClick}); err != nil {
+		t.Fatalf("SaveUnsubscribe() 에러 = %v", err)
+	}
+
+	m := &fakeMailer{}
+	result, err := sendNow(context.Background(), db, m, newRateController(100, nil, 0.9, time.Minute), semaphore.NewWeighted(1), content, req)
+	if !errors.Is(err, ErrRecipientUnsubscribed) {
+		t.Fatalf("sendNow() 에러 = %v, 예상 = %v", err, ErrRecipientUnsubscribed)
+	}
+	if result.Status != "unsubscribed" || len(m.sent) != 0 {
+		t.Errorf("결과 = %+v, 발송 수 = %d, 예상 = unsubscribed, 0", result, len(m.sent))
+	}
+	if saved := loadRequest(t, db, result.RequestID); saved.Status != model.EmailMsgStatusUnsubscribed {
+		t.Errorf("저장된 Status = %d, 예상 = %d", saved.Status, model.EmailMsgStatusUnsubscribed)
+	}
+}
@@ -93,6 +93,20 @@ func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
 	return duration
 }
 
+// getEnvAsFloat 환경 변수를 실수로 변환
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	val := GetEnv(key)
+	if val == "" {
+		return defaultVal
+	}
+	floatVal, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		log.Printf("Invalid float value for %s: %v, using default: %v", key, err, defaultVal)
+		return defaultVal
+	}
+	return floatVal
+}
+
 // CloseDB 데이터베이스 연결 종료
 func CloseDB() error {
 	if dbInstance == nil {
@@ -113,3 +127,13 @@ func CloseDB() error {
 func GetEnvAsInt(key string, defaultVal int) int {
 	return getEnvAsInt(key, defaultVal)
 }
+
+// GetEnvAsDuration 환경 변수를 Duration으로 변환 (외부 노출용)
+func GetEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	return getEnvAsDuration(key, defaultVal)
+}
+
+// GetEnvAsFloat 환경 변수를 실수로 변환 (외부 노출용)
+func GetEnvAsFloat(key string, defaultVal float64) float64 {
+	return getEnvAsFloat(key, defaultVal)
+}
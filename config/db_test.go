@@ -227,6 +227,36 @@ func TestGetEnvAsDuration(t *testing.T) {
 	}
 }
 
+// TestGetEnvAsFloat 실수형 환경 변수 파싱 테스트
+func TestGetEnvAsFloat(t *testing.T) {
+	tests := []struct {
+		name        string  // 테스트 케이스 이름
+		key         string  // 환경 변수 키
+		envValue    string  // 설정할 환경 변수 값
+		defaultVal  float64 // 기본값
+		setupEnv    bool    // 환경 변수를 설정할지 여부
+		expectedVal float64 // 예상 반환값
+	}{
+		{"정상적인 실수값 파싱", "TEST_FLOAT_1", "0.75", 0.9, true, 0.75},
+		{"정수 형식도 실수로 파싱", "TEST_FLOAT_2", "2", 0.9, true, 2},
+		{"환경 변수가 없는 경우 기본값 반환", "TEST_FLOAT_3", "", 0.9, false, 0.9},
+		{"잘못된 실수 형식 - 기본값 반환", "TEST_FLOAT_4", "ninety", 0.9, true, 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupEnv {
+				os.Setenv(tt.key, tt.envValue)
+			}
+			defer os.Unsetenv(tt.key)
+
+			if result := GetEnvAsFloat(tt.key, tt.defaultVal); result != tt.expectedVal {
+				t.Errorf("GetEnvAsFloat() = %v, 예상 = %v", result, tt.expectedVal)
+			}
+		})
+	}
+}
+
 // TestGetEnvAsIntBoundaryValues 경계값 테스트
 func TestGetEnvAsIntBoundaryValues(t *testing.T) {
 	tests := []struct {
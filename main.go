@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -48,9 +49,18 @@ func main() {
 		}
 	}()
 
-	go cmd.RunScheduler(ctx)
-	go cmd.RunSender(ctx)
+	// 백그라운드 작업은 종료 시 대기열 반환까지 완료한 뒤 DB 연결을 닫도록 대기
+	var wg sync.WaitGroup
+	for _, run := range []func(context.Context){cmd.RunScheduler, cmd.RunSender, cmd.RunReaper, cmd.RunEventConsumer, cmd.RunSuppressionSync} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(ctx)
+		}()
+	}
 
 	api.Run(ctx)
+	cancel()
+	wg.Wait()
 	log.Println("Application shutdown complete")
 }
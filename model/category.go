@@ -0,0 +1,248 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrCategoryNotFound 구독 카테고리가 없는 경우
+	ErrCategoryNotFound = errors.New("subscription category not found")
+	// ErrCategoryTopicConflict topicId가 이미 다른 카테고리에 속한 경우
+	ErrCategoryTopicConflict = errors.New("topic already belongs to another category")
+)
+
+// categoryNamePattern 카테고리 이름 형식 (소문자, 숫자, -, _)
+var categoryNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// SubscriptionCategory 수신자가 구독 여부를 선택할 수 있는 메일 분류 (marketing, billing 등)
+type SubscriptionCategory struct {
+	gorm.Model
+	Name        string `json:"name" gorm:"not null;type:varchar(50);uniqueIndex:idx_category_name"`
+	DisplayName string `json:"display_name" gorm:"type:varchar(100)"` // 환경 설정 페이지 표시 이름
+	Description string `json:"description" gorm:"type:text"`          // 환경 설정 페이지 설명
+	Topics      string `json:"topics" gorm:"type:json"`               // 이 카테고리로 분류할 topicId 목록 (JSON 배열)
+}
+
+func (SubscriptionCategory) TableName() string {
+	return "email_subscription_categories"
+}
+
+// Label 표시 이름 (없으면 카테고리 이름)
+func (c *SubscriptionCategory) Label() string {
+	if c.DisplayName != "" {
+		return c.DisplayName
+	}
+	return c.Name
+}
+
+// TopicList 카테고리로 분류할 topicId 목록
+func (c *SubscriptionCategory) TopicList() ([]string, error) {
+	if c.Topics == "" {
+		return nil, nil
+	}
+	var topics []string
+	if err := json.Unmarshal([]byte(c.Topics), &topics); err != nil {
+		return nil, fmt.Errorf("invalid category topics: %w", err)
+	}
+	return topics, nil
+}
+
+// SetTopics topicId 목록 저장 (공백 제거, 중복 제거)
+func (c *SubscriptionCategory) SetTopics(topics []string) {
+	seen := make(map[string]bool, len(topics))
+	list := make([]string, 0, len(topics))
+	for _, t := range topics {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		list = append(list, t)
+	}
+	if len(list) == 0 {
+		c.Topics = ""
+		return
+	}
+	b, _ := json.Marshal(list)
+	c.Topics = string(b)
+}
+
+// ValidCategoryName 카테고리 이름 형식 검증
+func ValidCategoryName(name string) bool {
+	return categoryNamePattern.MatchString(name)
+}
+
+// ListCategories 구독 카테고리 목록 (이름순)
+func ListCategories(db *gorm.DB) ([]SubscriptionCategory, error) {
+	var list []SubscriptionCategory
+	if err := db.Order("name ASC").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list subscription categories: %w", err)
+	}
+	return list, nil
+}
+
+// FindCategory 이름으로 구독 카테고리 조회
+func FindCategory(db *gorm.DB, name string) (*SubscriptionCategory, error) {
+	var c SubscriptionCategory
+	err := db.Where("name = ?", name).Take(&c).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subscription category %s: %w", name, err)
+	}
+	return &c, nil
+}
+
+// SaveCategory 구독 카테고리 생성 또는 수정 (하나의 topicId는 하나의 카테고리에만 속함)
+func SaveCategory(db *gorm.DB, c *SubscriptionCategory) error {
+	if !ValidCategoryName(c.Name) {
+		return fmt.Errorf("invalid category name: %s (lowercase letters, digits, '-' and '_', up to 50 characters)", c.Name)
+	}
+	topics, err := c.TopicList()
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		others, err := ListCategories(tx)
+		if err != nil {
+			return err
+		}
+		for _, other := range others {
+			if other.Name == c.Name {
+				c.ID = other.ID
+				c.CreatedAt = other.CreatedAt
+				continue
+			}
+			otherTopics, _ := other.TopicList()
+			for _, t := range topics {
+				for _, ot := range otherTopics {
+					if t == ot {
+						return fmt.Errorf("%w: %s (%s)", ErrCategoryTopicConflict, t, other.Name)
+					}
+				}
+			}
+		}
+		if err := tx.Save(c).Error; err != nil {
+			return fmt.Errorf("failed to save subscription category %s: %w", c.Name, err)
+		}
+		return nil
+	})
+}
+
+// DeleteCategory 구독 카테고리 삭제 (이미 분류된 요청과 수신자 설정은 유지)
+func DeleteCategory(db *gorm.DB, name string) error {
+	res := db.Where("name = ?", name).Delete(&SubscriptionCategory{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete subscription category %s: %w", name, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+// CategoryForTopic topicId가 속한 카테고리 이름 (없으면 빈 문자열)
+func CategoryForTopic(db *gorm.DB, topicId string) (string, error) {
+	if topicId == "" {
+		return "", nil
+	}
+	topics, err := TopicCategories(db)
+	if err != nil {
+		return "", err
+	}
+	return topics[topicId], nil
+}
+
+// TopicCategories 카테고리에 속한 topicId별 카테고리 이름
+func TopicCategories(db *gorm.DB) (map[string]string, error) {
+	list, err := ListCategories(db)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]string)
+	for _, c := range list {
+		topics, _ := c.TopicList()
+		for _, t := range topics {
+			found[t] = c.Name
+		}
+	}
+	return found, nil
+}
+
+// SubscriptionPreference 수신자의 카테고리별 구독 여부 (설정이 없으면 구독)
+type SubscriptionPreference struct {
+	gorm.Model
+	Email      string `json:"email" gorm:"not null;type:varchar(255);uniqueIndex:idx_preference_email_category"`
+	Category   string `json:"category" gorm:"not null;type:varchar(50);uniqueIndex:idx_preference_email_category"`
+	Subscribed bool   `json:"subscribed" gorm:"not null"`
+}
+
+func (SubscriptionPreference) TableName() string {
+	return "email_subscription_preferences"
+}
+
+// SavePreferences 수신자의 카테고리별 구독 여부 저장 (목록에 없는 카테고리는 변경하지 않음)
+func SavePreferences(db *gorm.DB, email string, prefs map[string]bool) error {
+	email = NormalizeEmail(email)
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for category, subscribed := range prefs {
+			p := &SubscriptionPreference{Email: email, Category: category}
+			err := tx.Where("email = ? AND category = ?", email, category).
+				Assign(map[string]interface{}{"subscribed": subscribed}).
+				FirstOrCreate(p).Error
+			if err != nil {
+				return fmt.Errorf("failed to save preference %s/%s: %w", email, category, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Preferences 수신자의 카테고리별 구독 여부 (설정이 없는 카테고리는 포함하지 않음)
+func Preferences(db *gorm.DB, email string) (map[string]bool, error) {
+	var list []SubscriptionPreference
+	if err := db.Where("email = ?", NormalizeEmail(email)).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to find preferences: %w", err)
+	}
+	prefs := make(map[string]bool, len(list))
+	for _, p := range list {
+		prefs[p.Category] = p.Subscribed
+	}
+	return prefs, nil
+}
+
+// OptedOutCategories 주소 목록 중 구독을 해지한 주소별 카테고리 집합 (정규화된 주소별)
+func OptedOutCategories(db *gorm.DB, emails []string) (map[string]map[string]bool, error) {
+	found := make(map[string]map[string]bool)
+	if len(emails) == 0 {
+		return found, nil
+	}
+
+	normalized := make([]string, 0, len(emails))
+	for _, e := range emails {
+		normalized = append(normalized, NormalizeEmail(e))
+	}
+
+	var list []SubscriptionPreference
+	if err := db.Where("email IN ? AND subscribed = ?", normalized, false).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to check preferences: %w", err)
+	}
+	for _, p := range list {
+		if found[p.Email] == nil {
+			found[p.Email] = make(map[string]bool)
+		}
+		found[p.Email][p.Category] = true
+	}
+	return found, nil
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSaveCategory 구독 카테고리 저장, 토픽 분류와 중복 토픽 검증 테스트
+func TestSaveCategory(t *testing.T) {
+	db := newTemplateTestDB(t)
+
+	marketing := &SubscriptionCategory{Name: "marketing", DisplayName: "Marketing"}
+	marketing.SetTopics([]string{" promo ", "newsletter", "promo", ""})
+	if err := SaveCategory(db, marketing); err != nil {
+		t.Fatalf("SaveCategory() 에러 = %v", err)
+	}
+	if marketing.Topics != `["promo","newsletter"]` {
+		t.Errorf("Topics = %s, 예상 = 공백과 중복이 제거된 목록", marketing.Topics)
+	}
+
+	tests := []struct {
+		name     string                // 테스트 케이스 이름
+		category *SubscriptionCategory // 저장할 카테고리
+		topics   []string              // 분류할 topicId 목록
+		wantErr  error                 // 예상 에러 (nil이면 성공)
+	}{
+		{"다른 카테고리", &SubscriptionCategory{Name: "billing"}, []string{"invoice"}, nil},
+		{"같은 이름은 갱신", &SubscriptionCategory{Name: "marketing", Description: "Offers"}, []string{"promo"}, nil},
+		{"다른 카테고리의 토픽", &SubscriptionCategory{Name: "product"}, []string{"invoice"}, ErrCategoryTopicConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.category.SetTopics(tt.topics)
+			err := SaveCategory(db, tt.category)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SaveCategory() 에러 = %v, 예상 = %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := SaveCategory(db, &SubscriptionCategory{Name: "Bad Name"}); err == nil {
+		t.Error("잘못된 카테고리 이름이 저장됨")
+	}
+
+	list, _ := ListCategories(db)
+	if len(list) != 2 {
+		t.Fatalf("카테고리 수 = %d, 예상 = 2", len(list))
+	}
+	for topic, want := range map[string]string{"promo": "marketing", "newsletter": "", "invoice": "billing", "": ""} {
+		got, err := CategoryForTopic(db, topic)
+		if err != nil || got != want {
+			t.Errorf("CategoryForTopic(%q) = %q, %v, 예상 = %q", topic, got, err, want)
+		}
+	}
+
+	if err := DeleteCategory(db, "billing"); err != nil {
+		t.Fatalf("DeleteCategory() 에러 = %v", err)
+	}
+	if err := DeleteCategory(db, "billing"); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("DeleteCategory() 중복 삭제 에러 = %v, 예상 = ErrCategoryNotFound", err)
+	}
+}
+
+// TestSavePreferences 수신자 카테고리별 구독 여부 저장과 구독 해지 조회 테스트
+func TestSavePreferences(t *testing.T) {
+	db := newTemplateTestDB(t)
+
+	if err := SavePreferences(db, " User@Example.com", map[string]bool{"marketing": false, "billing": true}); err != nil {
+		t.Fatalf("SavePreferences() 에러 = %v", err)
+	}
+	if err := SavePreferences(db, "user@example.com", map[string]bool{"billing": false}); err != nil {
+		t.Fatalf("SavePreferences() 에러 = %v", err)
+	}
+
+	prefs, err := Preferences(db, "USER@example.com")
+	if err != nil {
+		t.Fatalf("Preferences() 에러 = %v", err)
+	}
+	if len(prefs) != 2 || prefs["marketing"] || prefs["billing"] {
+		t.Errorf("Preferences() = %v, 예상 = 두 카테고리 모두 구독 해지", prefs)
+	}
+
+	if err := SavePreferences(db, "user@example.com", map[string]bool{"marketing": true}); err != nil {
+		t.Fatalf("SavePreferences() 에러 = %v", err)
+	}
+	optedOut, err := OptedOutCategories(db, []string{"user@example.com", "other@example.com"})
+	if err != nil {
+		t.Fatalf("OptedOutCategories() 에러 = %v", err)
+	}
+	if len(optedOut) != 1 || len(optedOut["user@example.com"]) != 1 || !optedOut["user@example.com"]["billing"] {
+		t.Errorf("OptedOutCategories() = %v, 예상 = billing만 구독 해지", optedOut)
+	}
+}
@@ -3,24 +3,119 @@ package model
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 const (
-	EmailMsgStatusCreated    = iota // 생성 완료
-	EmailMsgStatusProcessing        // 처리 중
-	EmailMsgStatusSent              // 발송 완료
-	EmailMsgStatusFailed            // 발송 실패
-	EmailMsgStatusStopped           // 중지됨
+	EmailMsgStatusCreated      = iota // 생성 완료
+	EmailMsgStatusProcessing          // 처리 중
+	EmailMsgStatusSent                // 발송 완료
+	EmailMsgStatusFailed              // 발송 실패
+	EmailMsgStatusStopped             // 중지됨
+	EmailMsgStatusDelivered           // 전달 완료 (SES Delivery)
+	EmailMsgStatusBounced             // 영구 반송 (SES Permanent Bounce)
+	EmailMsgStatusSoftBounced         // 일시 반송 (SES Transient, Undetermined Bounce)
+	EmailMsgStatusComplained          // 수신 거부 신고 (SES Complaint)
+	EmailMsgStatusRejected            // 발송 거부 (SES Reject)
+	EmailMsgStatusDelayed             // 전달 지연 (SES DeliveryDelay)
+	EmailMsgStatusSuppressed          // 수신 거부 목록에 있어 발송하지 않음
+	EmailMsgStatusUnsubscribed        // 수신자가 수신 거부하여 발송하지 않음
+	EmailMsgStatusPaused              // 일시 중지됨 (재개하면 대기 상태로 복귀)
 )
 
+// deliveryRanks 발송 이후 상태의 우선순위 (높을수록 최종 상태, 없으면 SES 이벤트로 갱신하지 않음)
+var deliveryRanks = map[int]int{
+	EmailMsgStatusSent:        1,
+	EmailMsgStatusDelayed:     2,
+	EmailMsgStatusSoftBounced: 3,
+	EmailMsgStatusDelivered:   4,
+	EmailMsgStatusBounced:     5,
+	EmailMsgStatusRejected:    5,
+	EmailMsgStatusComplained:  6,
+}
+
+// SentStatuses 발송 완료 이후의 상태 목록 (발송 완료와 SES 전달 상태)
+func SentStatuses() []int {
+	statuses := make([]int, 0, len(deliveryRanks))
+	for status := range deliveryRanks {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	return statuses
+}
+
+// CountSent 지정 시각 이후 발송 완료된 요청 수 (발송 완료 시각은 updated_at)
+func CountSent(db *gorm.DB, since time.Time) (int64, error) {
+	var cnt int64
+	err := db.Model(&Request{}).
+		Where("updated_at >= ?", since).
+		Where("status IN ?", SentStatuses()).
+		Count(&cnt).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count sent requests: %w", err)
+	}
+	return cnt, nil
+}
+
+// AdvanceDeliveryStatus 요청 상태를 우선순위가 더 높은 전달 상태로만 갱신
+// 순서가 뒤바뀌거나 중복 전달된 이벤트는 상태를 되돌리지 않으며, 갱신 여부를 반환
+// 발송 수 집계가 발송 완료 시각(updated_at)을 기준으로 하므로 updated_at은 변경하지 않음
+func AdvanceDeliveryStatus(tx *gorm.DB, requestId uint, status int) (bool, error) {
+	rank, ok := deliveryRanks[status]
+	if !ok {
+		return false, fmt.Errorf("invalid delivery status: %d", status)
+	}
+
+	var lower []int
+	for s, r := range deliveryRanks {
+		if r < rank {
+			lower = append(lower, s)
+		}
+	}
+	if len(lower) == 0 {
+		return false, nil
+	}
+
+	res := tx.Model(&Request{}).
+		Where("id = ? AND status IN ?", requestId, lower).
+		UpdateColumn("status", status)
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to update delivery status (requestId=%d): %w", requestId, res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+const (
+	EmailPriorityNormal = iota // 일반 발송 (대량, 마케팅)
+	EmailPriorityHigh          // 우선 발송 (트랜잭션 메일: 비밀번호 재설정, 인증 등)
+)
+
+// ParsePriority 우선순위 이름을 값으로 변환 (빈 문자열은 일반 우선순위)
+func ParsePriority(name string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "normal":
+		return EmailPriorityNormal, nil
+	case "high":
+		return EmailPriorityHigh, nil
+	default:
+		return 0, fmt.Errorf("invalid priority: %s", name)
+	}
+}
+
 // Content 이메일 컨텐츠
 type Content struct {
 	gorm.Model
-	Subject string `json:"subject" gorm:"not null;type:varchar(255);index:idx_subject"`
-	Content string `json:"content" gorm:"not null;type:text"`
+	Subject         string `json:"subject" gorm:"not null;type:varchar(255);index:idx_subject"`
+	Content         string `json:"content" gorm:"not null;type:text"`
+	Text            string `json:"text" gorm:"type:text"`
+	DefaultVars     string `json:"default_vars" gorm:"type:json"`
+	Strict          bool   `json:"strict" gorm:"default:false;not null"`
+	NoClickTracking bool   `json:"no_click_tracking" gorm:"default:false;not null"`
+	TemplateId      *uint  `json:"template_id" gorm:"index:idx_content_template"`
 }
 
 func (Content) TableName() string {
@@ -30,14 +125,21 @@ func (Content) TableName() string {
 // Request 이메일 발송 요청
 type Request struct {
 	gorm.Model
-	TopicId     string     `json:"topic_id" gorm:"index:idx_topic_status;default:'';type:varchar(50)"`
-	MessageId   string     `json:"message_id" gorm:"type:varchar(100);index:idx_message_id"`
-	To          string     `json:"to" gorm:"not null;type:varchar(255);index:idx_recipient"`
-	ContentId   uint       `json:"content_id" gorm:"index;not null"`
-	Content     Content    `json:"content" gorm:"foreignKey:ContentId;references:ID"`
-	ScheduledAt *time.Time `json:"scheduled_at" gorm:"not null;index:idx_scheduled_status;type:timestamp"`
-	Status      int        `json:"status" gorm:"default:0;index:idx_topic_status,idx_scheduled_status;not null;type:smallint"`
-	Error       string     `json:"error" gorm:"type:varchar(255)"`
+	TopicId       string     `json:"topic_id" gorm:"index:idx_topic_status;default:'';type:varchar(50)"`
+	Category      string     `json:"category" gorm:"default:'';type:varchar(50)"` // 구독 카테고리 (수신자가 구독을 해지했으면 발송하지 않음)
+	MessageId     string     `json:"message_id" gorm:"type:varchar(100);index:idx_message_id"`
+	To            string     `json:"to" gorm:"not null;type:varchar(255);index:idx_recipient"`
+	ContentId     uint       `json:"content_id" gorm:"index;not null"`
+	Content       Content    `json:"content" gorm:"foreignKey:ContentId;references:ID"`
+	ScheduledAt   *time.Time `json:"scheduled_at" gorm:"not null;index:idx_scheduled_status;type:timestamp"`
+	Status        int        `json:"status" gorm:"default:0;index:idx_topic_status,idx_scheduled_status,idx_status_priority;not null;type:smallint"`
+	Priority      int        `json:"priority" gorm:"default:0;index:idx_status_priority;not null;type:smallint"`
+	Vars          string     `json:"vars" gorm:"type:json"`
+	Error         string     `json:"error" gorm:"type:varchar(255)"`
+	Attempts      int        `json:"attempts" gorm:"default:0;not null"`
+	NextAttemptAt *time.Time `json:"next_attempt_at" gorm:"type:timestamp"`
+	LockedUntil   *time.Time `json:"locked_until" gorm:"type:timestamp;index:idx_status_locked"`
+	LeaseOwner    string     `json:"lease_owner" gorm:"type:varchar(100);default:''"`
 }
 
 func (Request) TableName() string {
@@ -58,6 +160,12 @@ func (r *Request) Validate() error {
 	return nil
 }
 
+// TrackingEvents 이 서비스의 추적 URL(열람 픽셀, 클릭 리다이렉트)로 기록한 결과만 조회하는 조건
+// SES가 보낸 Open/Click 이벤트는 같은 상태 이름을 쓰지만 SNS 메시지 ID가 있으므로 제외
+func TrackingEvents(db *gorm.DB) *gorm.DB {
+	return db.Where("sns_message_id IS NULL")
+}
+
 // Result AWS SES 이메일 발송 결과
 type Result struct {
 	gorm.Model
@@ -65,12 +173,61 @@ type Result struct {
 	Request   Request `json:"request" gorm:"foreignKey:RequestId;references:ID"`
 	Status    string  `json:"status" gorm:"not null;index:idx_request_status;type:varchar(50)"`
 	Raw       string  `json:"raw" gorm:"type:json"`
+
+	// SNS 전달 메시지 ID (중복 전달 방지, 추적 이벤트는 NULL)
+	SnsMessageId *string `json:"sns_message_id" gorm:"type:varchar(100);uniqueIndex:idx_result_sns_message_id"`
+
+	// SES 이벤트 구조화 필드 (추적 이벤트는 비어 있음)
+	MessageId             string            `json:"message_id" gorm:"type:varchar(255);index:idx_result_message_id"`
+	EventAt               *time.Time        `json:"event_at"`
+	BounceType            string            `json:"bounce_type" gorm:"type:varchar(50);index:idx_result_bounce"`
+	BounceSubType         string            `json:"bounce_sub_type" gorm:"type:varchar(50);index:idx_result_bounce"`
+	ComplaintFeedbackType string            `json:"complaint_feedback_type" gorm:"type:varchar(50)"`
+	ComplaintSubType      string            `json:"complaint_sub_type" gorm:"type:varchar(50)"`
+	DelayType             string            `json:"delay_type" gorm:"type:varchar(50)"`
+	SmtpResponse          string            `json:"smtp_response" gorm:"type:text"`
+	ProcessingTimeMillis  int64             `json:"processing_time_millis"`
+	ReportingMTA          string            `json:"reporting_mta" gorm:"type:varchar(255)"`
+	Reason                string            `json:"reason" gorm:"type:text"` // 발송 거부 사유, 렌더링 실패 메시지
+	Link                  string            `json:"link" gorm:"type:text"`
+	UserAgent             string            `json:"user_agent" gorm:"type:text"`
+	IpAddress             string            `json:"ip_address" gorm:"type:varchar(64)"`
+	Recipients            []ResultRecipient `json:"recipients,omitempty" gorm:"foreignKey:ResultId"`
 }
 
 func (Result) TableName() string {
 	return "email_results"
 }
 
+// ResultRecipient SES 이벤트의 수신자별 결과 (반송, 수신 거부 신고, 지연, 전달)
+type ResultRecipient struct {
+	gorm.Model
+	ResultId       uint   `json:"result_id" gorm:"not null;index"`
+	Email          string `json:"email" gorm:"not null;type:varchar(255);index"`
+	Action         string `json:"action" gorm:"type:varchar(50)"`
+	Status         string `json:"status" gorm:"type:varchar(50)"`
+	DiagnosticCode string `json:"diagnostic_code" gorm:"type:text"`
+}
+
+func (ResultRecipient) TableName() string {
+	return "email_result_recipients"
+}
+
+// OrphanEvent 요청과 연결하지 못한 SES 이벤트 (X-Request-ID 헤더와 message_id 모두 일치하지 않음)
+type OrphanEvent struct {
+	gorm.Model
+	SnsMessageId     *string    `json:"sns_message_id" gorm:"type:varchar(100);uniqueIndex:idx_orphan_sns_message_id"`
+	MessageId        string     `json:"message_id" gorm:"not null;type:varchar(255);index:idx_orphan_message_id"`
+	EventType        string     `json:"event_type" gorm:"not null;type:varchar(50)"`
+	EventAt          *time.Time `json:"event_at"`
+	HeadersTruncated bool       `json:"headers_truncated" gorm:"default:false;not null"`
+	Raw              string     `json:"raw" gorm:"type:json"`
+}
+
+func (OrphanEvent) TableName() string {
+	return "email_orphan_events"
+}
+
 // AutoMigrate 데이터베이스 마이그레이션 실행
 func AutoMigrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(&Content{}); err != nil {
@@ -87,6 +244,13 @@ func AutoMigrate(db *gorm.DB) error {
 		return fmt.Errorf("email_requests table was not created")
 	}
 
+	if err := db.AutoMigrate(&Template{}); err != nil {
+		return fmt.Errorf("failed to migrate Template: %w", err)
+	}
+	if !db.Migrator().HasTable(&Template{}) {
+		return fmt.Errorf("email_templates table was not created")
+	}
+
 	if err := db.AutoMigrate(&Result{}); err != nil {
 		return fmt.Errorf("failed to migrate Result: %w", err)
 	}
@@ -94,6 +258,62 @@ func AutoMigrate(db *gorm.DB) error {
 		return fmt.Errorf("email_results table was not created")
 	}
 
+	if err := db.AutoMigrate(&ResultRecipient{}); err != nil {
+		return fmt.Errorf("failed to migrate ResultRecipient: %w", err)
+	}
+	if !db.Migrator().HasTable(&ResultRecipient{}) {
+		return fmt.Errorf("email_result_recipients table was not created")
+	}
+
+	if err := db.AutoMigrate(&OrphanEvent{}); err != nil {
+		return fmt.Errorf("failed to migrate OrphanEvent: %w", err)
+	}
+	if !db.Migrator().HasTable(&OrphanEvent{}) {
+		return fmt.Errorf("email_orphan_events table was not created")
+	}
+
+	if err := db.AutoMigrate(&Suppression{}); err != nil {
+		return fmt.Errorf("failed to migrate Suppression: %w", err)
+	}
+	if !db.Migrator().HasTable(&Suppression{}) {
+		return fmt.Errorf("email_suppressions table was not created")
+	}
+
+	if err := db.AutoMigrate(&Unsubscribe{}); err != nil {
+		return fmt.Errorf("failed to migrate Unsubscribe: %w", err)
+	}
+	if !db.Migrator().HasTable(&Unsubscribe{}) {
+		return fmt.Errorf("email_unsubscribes table was not created")
+	}
+
+	if err := db.AutoMigrate(&SubscriptionCategory{}); err != nil {
+		return fmt.Errorf("failed to migrate SubscriptionCategory: %w", err)
+	}
+	if !db.Migrator().HasTable(&SubscriptionCategory{}) {
+		return fmt.Errorf("email_subscription_categories table was not created")
+	}
+
+	if err := db.AutoMigrate(&SubscriptionPreference{}); err != nil {
+		return fmt.Errorf("failed to migrate SubscriptionPreference: %w", err)
+	}
+	if !db.Migrator().HasTable(&SubscriptionPreference{}) {
+		return fmt.Errorf("email_subscription_preferences table was not created")
+	}
+
+	if err := db.AutoMigrate(&SyncState{}); err != nil {
+		return fmt.Errorf("failed to migrate SyncState: %w", err)
+	}
+	if !db.Migrator().HasTable(&SyncState{}) {
+		return fmt.Errorf("sync_states table was not created")
+	}
+
+	if err := db.AutoMigrate(&SNSSubscription{}); err != nil {
+		return fmt.Errorf("failed to migrate SNSSubscription: %w", err)
+	}
+	if !db.Migrator().HasTable(&SNSSubscription{}) {
+		return fmt.Errorf("sns_subscriptions table was not created")
+	}
+
 	// WAL 체크포인트를 강제 실행하여 데이터를 메인 DB 파일에 기록
 	if err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)").Error; err != nil {
 		return fmt.Errorf("failed to execute WAL checkpoint: %w", err)
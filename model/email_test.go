@@ -100,6 +100,15 @@ func TestEmailMsgStatus(t *testing.T) {
 		{"발송 완료 상태", EmailMsgStatusSent, 2},
 		{"발송 실패 상태", EmailMsgStatusFailed, 3},
 		{"중지됨 상태", EmailMsgStatusStopped, 4},
+		{"전달 완료 상태", EmailMsgStatusDelivered, 5},
+		{"영구 반송 상태", EmailMsgStatusBounced, 6},
+		{"일시 반송 상태", EmailMsgStatusSoftBounced, 7},
+		{"수신 거부 신고 상태", EmailMsgStatusComplained, 8},
+		{"발송 거부 상태", EmailMsgStatusRejected, 9},
+		{"전달 지연 상태", EmailMsgStatusDelayed, 10},
+		{"수신 거부 상태", EmailMsgStatusSuppressed, 11},
+		{"수신자 수신 거부 상태", EmailMsgStatusUnsubscribed, 12},
+		{"일시 중지 상태", EmailMsgStatusPaused, 13},
 	}
 
 	for _, tt := range tests {
@@ -140,3 +149,30 @@ func TestResultTableName(t *testing.T) {
 		t.Errorf("TableName() = %v, 예상 = %v", r.TableName(), expected)
 	}
 }
+
+// TestParsePriority 우선순위 이름 변환 테스트
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		name     string // 테스트 케이스 이름
+		input    string // 우선순위 이름
+		expected int    // 예상 우선순위 값
+		wantErr  bool   // 에러 발생 여부
+	}{
+		{"빈 값은 일반 우선순위", "", EmailPriorityNormal, false},
+		{"normal", "normal", EmailPriorityNormal, false},
+		{"high (대소문자 무시)", " HIGH ", EmailPriorityHigh, false},
+		{"알 수 없는 우선순위", "urgent", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePriority(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriority() 에러 = %v, 에러 예상 = %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParsePriority() = %d, 예상 = %d", got, tt.expected)
+			}
+		})
+	}
+}
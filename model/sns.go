@@ -0,0 +1,54 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SNS 구독 상태
+const (
+	SNSSubscriptionPending      = "pending"      // 확인 대기 (자동 확인 대상이 아닌 토픽)
+	SNSSubscriptionConfirmed    = "confirmed"    // 구독 확인 완료
+	SNSSubscriptionFailed       = "failed"       // 구독 확인 실패
+	SNSSubscriptionUnsubscribed = "unsubscribed" // 구독 해지
+)
+
+// SNSSubscription 발송 결과를 전달하는 SNS 토픽 구독
+type SNSSubscription struct {
+	gorm.Model
+	TopicArn        string     `json:"topic_arn" gorm:"not null;type:varchar(255);uniqueIndex:idx_sns_topic"`
+	SubscriptionArn string     `json:"subscription_arn" gorm:"type:varchar(255)"`
+	Status          string     `json:"status" gorm:"not null;type:varchar(20)"`
+	SubscribeURL    string     `json:"subscribe_url" gorm:"type:text"`
+	LastError       string     `json:"last_error" gorm:"type:text"`
+	ConfirmedAt     *time.Time `json:"confirmed_at"`
+	UnsubscribedAt  *time.Time `json:"unsubscribed_at"`
+}
+
+func (SNSSubscription) TableName() string {
+	return "sns_subscriptions"
+}
+
+// SaveSNSSubscription 토픽별 구독 상태 저장 (없으면 생성)
+func SaveSNSSubscription(db *gorm.DB, topicArn string, update func(sub *SNSSubscription)) (*SNSSubscription, error) {
+	sub := &SNSSubscription{}
+	if err := db.Where(SNSSubscription{TopicArn: topicArn}).FirstOrInit(sub).Error; err != nil {
+		return nil, fmt.Errorf("failed to find SNS subscription: %w", err)
+	}
+	update(sub)
+	if err := db.Save(sub).Error; err != nil {
+		return nil, fmt.Errorf("failed to save SNS subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// ListSNSSubscriptions 구독 목록 (토픽 이름순)
+func ListSNSSubscriptions(db *gorm.DB) ([]SNSSubscription, error) {
+	var subs []SNSSubscription
+	if err := db.Order("topic_arn ASC").Find(&subs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list SNS subscriptions: %w", err)
+	}
+	return subs, nil
+}
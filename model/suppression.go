@@ -0,0 +1,244 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 수신 거부 사유
+const (
+	SuppressionReasonBounce    = "BOUNCE"
+	SuppressionReasonComplaint = "COMPLAINT"
+	SuppressionReasonManual    = "MANUAL"
+)
+
+// 수신 거부 등록 경로
+const (
+	SuppressionSourceEvent  = "event"  // SES 반송/수신 거부 신고 이벤트
+	SuppressionSourceAPI    = "api"    // API로 직접 등록
+	SuppressionSourceImport = "import" // API 일괄 등록
+	SuppressionSourceSES    = "ses"    // SES 계정 수신 거부 목록에서 가져옴
+)
+
+// ErrSuppressionNotFound 수신 거부 목록에 없는 주소인 경우
+var ErrSuppressionNotFound = errors.New("suppression not found")
+
+// Suppression 발송하지 않을 수신 주소 (주소별 하나, 만료 시각이 지나면 발송 재개)
+type Suppression struct {
+	gorm.Model
+	Email     string     `json:"email" gorm:"not null;type:varchar(255);uniqueIndex:idx_suppression_email"`
+	Reason    string     `json:"reason" gorm:"not null;type:varchar(20);index:idx_suppression_reason"`
+	Source    string     `json:"source" gorm:"not null;type:varchar(20)"`
+	ResultId  *uint      `json:"result_id"`                                       // 등록 원인이 된 SES 이벤트 결과
+	MessageId string     `json:"message_id" gorm:"type:varchar(255)"`             // 등록 원인이 된 SES 메시지 ID
+	Detail    string     `json:"detail" gorm:"type:text"`                         // 반송 유형, 진단 메시지, 메모 등
+	ExpiresAt *time.Time `json:"expires_at" gorm:"index:idx_suppression_expires"` // 비어 있으면 만료 없음
+	// SES 계정 수신 거부 목록에 반영한 시각 (비어 있으면 반영 대기, 저장이나 해제 시 초기화)
+	SesSyncedAt *time.Time `json:"ses_synced_at" gorm:"index:idx_suppression_ses_synced"`
+}
+
+func (Suppression) TableName() string {
+	return "email_suppressions"
+}
+
+// NormalizeEmail 수신 거부 비교용 주소 (앞뒤 공백 제거, 소문자)
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidSuppressionReason 지원하는 수신 거부 사유인지 여부
+func ValidSuppressionReason(reason string) bool {
+	switch reason {
+	case SuppressionReasonBounce, SuppressionReasonComplaint, SuppressionReasonManual:
+		return true
+	}
+	return false
+}
+
+// Active 지정 시각에 수신 거부가 유효한지 여부
+func (s *Suppression) Active(now time.Time) bool {
+	return s.ExpiresAt == nil || s.ExpiresAt.After(now)
+}
+
+// activeScope 만료되지 않은 수신 거부 조건
+func activeScope(now time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("expires_at IS NULL OR expires_at > ?", now)
+	}
+}
+
+// SaveSuppression 수신 거부 등록 (이미 있으면 사유, 원인, 만료 시각을 갱신하고 삭제된 항목은 복원)
+func SaveSuppression(db *gorm.DB, s *Suppression) error {
+	s.Email = NormalizeEmail(s.Email)
+	if s.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if !ValidSuppressionReason(s.Reason) {
+		return fmt.Errorf("invalid suppression reason: %s", s.Reason)
+	}
+
+	var existing Suppression
+	err := db.Unscoped().Where("email = ?", s.Email).Take(&existing).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to find suppression %s: %w", s.Email, err)
+	}
+	if err == nil {
+		s.ID = existing.ID
+		s.CreatedAt = existing.CreatedAt
+	}
+	s.DeletedAt = gorm.DeletedAt{}
+
+	if err := db.Unscoped().Save(s).Error; err != nil {
+		return fmt.Errorf("failed to save suppression %s: %w", s.Email, err)
+	}
+	return nil
+}
+
+// RemoveSuppression 수신 거부 해제 (soft delete, SES 계정 수신 거부 목록 반영 대기로 전환)
+func RemoveSuppression(db *gorm.DB, email string) error {
+	res := db.Model(&Suppression{}).Where("email = ?", NormalizeEmail(email)).Updates(map[string]interface{}{
+		"deleted_at":    time.Now().UTC(),
+		"ses_synced_at": nil,
+	})
+	if res.Error != nil {
+		return fmt.Errorf("failed to remove suppression %s: %w", email, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrSuppressionNotFound
+	}
+	return nil
+}
+
+// FindSuppression 주소의 수신 거부 조회 (만료된 항목 포함)
+func FindSuppression(db *gorm.DB, email string) (*Suppression, error) {
+	var s Suppression
+	err := db.Where("email = ?", NormalizeEmail(email)).Take(&s).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSuppressionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find suppression %s: %w", email, err)
+	}
+	return &s, nil
+}
+
+// ListSuppressions 수신 거부 목록 조회 (reason이 비어 있으면 전체, 최근 등록순)
+func ListSuppressions(db *gorm.DB, reason string, limit, offset int) ([]Suppression, int64, error) {
+	query := db.Model(&Suppression{})
+	if reason != "" {
+		query = query.Where("reason = ?", reason)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count suppressions: %w", err)
+	}
+	var list []Suppression
+	if err := query.Order("updated_at DESC, id DESC").Limit(limit).Offset(offset).Find(&list).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list suppressions: %w", err)
+	}
+	return list, total, nil
+}
+
+// ActiveSuppressions 주소 목록 중 수신 거부가 유효한 주소 (정규화된 주소별)
+func ActiveSuppressions(db *gorm.DB, emails []string, now time.Time) (map[string]Suppression, error) {
+	found := make(map[string]Suppression)
+	if len(emails) == 0 {
+		return found, nil
+	}
+
+	normalized := make([]string, 0, len(emails))
+	for _, e := range emails {
+		normalized = append(normalized, NormalizeEmail(e))
+	}
+
+	var list []Suppression
+	if err := db.Scopes(activeScope(now)).Where("email IN ?", normalized).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to check suppressions: %w", err)
+	}
+	for _, s := range list {
+		found[s.Email] = s
+	}
+	return found, nil
+}
+
+// PendingSESAdditions SES 계정 수신 거부 목록에 추가할 항목 (API, 일괄 등록으로 추가한 만료 없는 항목)
+// SES 목록에는 만료가 없으므로 만료 시각이 있는 항목은 로컬에만 유지
+func PendingSESAdditions(db *gorm.DB, limit int) ([]Suppression, error) {
+	var list []Suppression
+	err := db.Where("ses_synced_at IS NULL AND expires_at IS NULL AND source IN ?",
+		[]string{SuppressionSourceAPI, SuppressionSourceImport}).
+		Order("id ASC").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending SES suppression additions: %w", err)
+	}
+	return list, nil
+}
+
+// PendingSESRemovals SES 계정 수신 거부 목록에서 삭제할 항목 (로컬에서 해제한 항목)
+func PendingSESRemovals(db *gorm.DB, limit int) ([]Suppression, error) {
+	var list []Suppression
+	err := db.Unscoped().Where("deleted_at IS NOT NULL AND ses_synced_at IS NULL").
+		Order("id ASC").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending SES suppression removals: %w", err)
+	}
+	return list, nil
+}
+
+// MarkSESSynced SES 계정 수신 거부 목록 반영 완료 기록 (해제된 항목 포함, 기록했으면 true)
+// SES에 반영하는 동안 항목이 다시 저장되거나 해제되었으면 기록하지 않고 다음 동기화에서 다시 반영
+func MarkSESSynced(db *gorm.DB, s *Suppression, now time.Time) (bool, error) {
+	query := db.Unscoped().Model(&Suppression{}).Where("id = ? AND updated_at = ?", s.ID, s.UpdatedAt)
+	if s.DeletedAt.Valid {
+		query = query.Where("deleted_at = ?", s.DeletedAt.Time)
+	} else {
+		query = query.Where("deleted_at IS NULL")
+	}
+	res := query.Update("ses_synced_at", now)
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to mark suppression %d as synced: %w", s.ID, res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// ApplySESSuppression SES 계정 수신 거부 목록 항목을 로컬 목록에 반영 (로컬 목록이 바뀌었으면 true)
+//   - 로컬에 없으면 source=ses로 추가
+//   - 로컬에 있으면 내용은 유지하고 반영 완료로 기록
+//   - 로컬에서 해제했지만 아직 SES에 반영하지 않았으면 무시 (다음 동기화에서 SES에서 삭제)
+//   - 해제를 SES에 반영한 뒤 SES에서 다시 등록된 항목만 복원
+func ApplySESSuppression(db *gorm.DB, email, reason string, lastUpdate, now time.Time) (bool, error) {
+	email = NormalizeEmail(email)
+	var existing Suppression
+	err := db.Unscoped().Where("email = ?", email).Take(&existing).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, fmt.Errorf("failed to find suppression %s: %w", email, err)
+	}
+
+	if err == nil && !existing.DeletedAt.Valid {
+		if existing.SesSyncedAt == nil {
+			_, err := MarkSESSynced(db, &existing, now)
+			return false, err
+		}
+		return false, nil
+	}
+	if err == nil && (existing.SesSyncedAt == nil || !lastUpdate.After(existing.DeletedAt.Time)) {
+		return false, nil
+	}
+
+	s := &Suppression{
+		Email:       email,
+		Reason:      reason,
+		Source:      SuppressionSourceSES,
+		Detail:      "SES account suppression list",
+		SesSyncedAt: &now,
+	}
+	if err := SaveSuppression(db, s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
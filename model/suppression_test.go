@@ -0,0 +1,236 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TestSaveSuppression 수신 거부 등록, 갱신, 해제 후 재등록 테스트
+func TestSaveSuppression(t *testing.T) {
+	db := newTemplateTestDB(t)
+
+	first := &Suppression{Email: " User@Example.com ", Reason: SuppressionReasonBounce, Source: SuppressionSourceEvent}
+	if err := SaveSuppression(db, first); err != nil {
+		t.Fatalf("SaveSuppression() 에러 = %v", err)
+	}
+	if first.Email != "user@example.com" {
+		t.Errorf("Email = %q, 예상 = 정규화된 주소", first.Email)
+	}
+
+	// 같은 주소는 새 항목을 만들지 않고 사유를 갱신
+	again := &Suppression{Email: "user@example.com", Reason: SuppressionReasonComplaint, Source: SuppressionSourceEvent}
+	if err := SaveSuppression(db, again); err != nil {
+		t.Fatalf("SaveSuppression() 에러 = %v", err)
+	}
+	if again.ID != first.ID {
+		t.Errorf("ID = %d, 예상 = %d (기존 항목 갱신)", again.ID, first.ID)
+	}
+
+	if err := RemoveSuppression(db, "USER@example.com"); err != nil {
+		t.Fatalf("RemoveSuppression() 에러 = %v", err)
+	}
+	if err := RemoveSuppression(db, "user@example.com"); !errors.Is(err, ErrSuppressionNotFound) {
+		t.Errorf("RemoveSuppression() 에러 = %v, 예상 = %v", err, ErrSuppressionNotFound)
+	}
+
+	// 해제된 주소를 다시 등록하면 삭제된 항목을 복원
+	restored := &Suppression{Email: "user@example.com", Reason: SuppressionReasonManual, Source: SuppressionSourceAPI}
+	if err := SaveSuppression(db, restored); err != nil {
+		t.Fatalf("SaveSuppression() 재등록 에러 = %v", err)
+	}
+	found, err := FindSuppression(db, "user@example.com")
+	if err != nil {
+		t.Fatalf("FindSuppression() 에러 = %v", err)
+	}
+	if found.ID != first.ID || found.Reason != SuppressionReasonManual {
+		t.Errorf("복원된 항목 = %+v", found)
+	}
+
+	if err := SaveSuppression(db, &Suppression{Email: "a@example.com", Reason: "UNKNOWN"}); err == nil {
+		t.Error("지원하지 않는 사유에 대해 에러가 예상됨")
+	}
+}
+
+// TestActiveSuppressions 만료되지 않은 수신 거부만 조회
+func TestActiveSuppressions(t *testing.T) {
+	db := newTemplateTestDB(t)
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	for _, s := range []*Suppression{
+		{Email: "forever@example.com", Reason: SuppressionReasonBounce, Source: SuppressionSourceEvent},
+		{Email: "later@example.com", Reason: SuppressionReasonManual, Source: SuppressionSourceAPI, ExpiresAt: &future},
+		{Email: "expired@example.com", Reason: SuppressionReasonManual, Source: SuppressionSourceAPI, ExpiresAt: &past},
+	} {
+		if err := SaveSuppression(db, s); err != nil {
+			t.Fatalf("SaveSuppression() 에러 = %v", err)
+		}
+	}
+
+	got, err := ActiveSuppressions(db, []string{"Forever@example.com", "later@example.com", "expired@example.com", "none@example.com"}, now)
+	if err != nil {
+		t.Fatalf("ActiveSuppressions() 에러 = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("유효한 수신 거부 수 = %d, 예상 = 2", len(got))
+	}
+	for _, email := range []string{"forever@example.com", "later@example.com"} {
+		if _, ok := got[email]; !ok {
+			t.Errorf("%s가 수신 거부로 조회되지 않음", email)
+		}
+	}
+}
+
+// TestApplySESSuppression SES 계정 수신 거부 목록 항목의 로컬 반영 규칙 테스트
+func TestApplySESSuppression(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name        string                          // 테스트 케이스 이름
+		setup       func(t *testing.T, db *gorm.DB) // 로컬 목록 준비
+		lastUpdate  time.Time                       // SES 목록 갱신 시각
+		wantChanged bool                            // 로컬 목록 변경 예상 여부
+		wantSource  string                          // 예상 등록 경로 (빈 값이면 해제 상태 유지)
+	}{
+		{
+			name:        "로컬에 없으면 추가",
+			setup:       func(t *testing.T, db *gorm.DB) {},
+			lastUpdate:  now,
+			wantChanged: true,
+			wantSource:  SuppressionSourceSES,
+		},
+		{
+			name: "로컬에 있으면 유지",
+			setup: func(t *testing.T, db *gorm.DB) {
+				saveTestSuppression(t, db, SuppressionSourceAPI)
+			},
+			lastUpdate: now,
+			wantSource: SuppressionSourceAPI,
+		},
+		{
+			name: "해제를 SES에 반영하기 전이면 복원하지 않음",
+			setup: func(t *testing.T, db *gorm.DB) {
+				saveTestSuppression(t, db, SuppressionSourceAPI)
+				if err := RemoveSuppression(db, "user@example.com"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			lastUpdate: now.Add(time.Hour),
+		},
+		{
+			name: "해제 반영 후 SES에서 다시 등록되면 복원",
+			setup: func(t *testing.T, db *gorm.DB) {
+				s := saveTestSuppression(t, db, SuppressionSourceAPI)
+				if err := RemoveSuppression(db, "user@example.com"); err != nil {
+					t.Fatal(err)
+				}
+				removed := loadTestSuppression(t, db, s.ID)
+				if synced, err := MarkSESSynced(db, &removed, now); err != nil || !synced {
+					t.Fatalf("MarkSESSynced() = %v, %v", synced, err)
+				}
+			},
+			lastUpdate:  now.Add(time.Hour),
+			wantChanged: true,
+			wantSource:  SuppressionSourceSES,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTemplateTestDB(t)
+			tt.setup(t, db)
+
+			changed, err := ApplySESSuppression(db, "User@example.com", SuppressionReasonBounce, tt.lastUpdate, now)
+			if err != nil {
+				t.Fatalf("ApplySESSuppression() 에러 = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, 예상 = %v", changed, tt.wantChanged)
+			}
+
+			s, err := FindSuppression(db, "user@example.com")
+			if tt.wantSource == "" {
+				if !errors.Is(err, ErrSuppressionNotFound) {
+					t.Errorf("FindSuppression() = %+v, %v, 예상 = 해제 상태", s, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindSuppression() 에러 = %v", err)
+			}
+			if s.Source != tt.wantSource || s.SesSyncedAt == nil {
+				t.Errorf("Source = %s, SesSyncedAt = %v, 예상 = %s, 반영 완료", s.Source, s.SesSyncedAt, tt.wantSource)
+			}
+		})
+	}
+}
+
+// saveTestSuppression 테스트용 수신 거부 등록 (user@example.com)
+func saveTestSuppression(t *testing.T, db *gorm.DB, source string) *Suppression {
+	t.Helper()
+	s := &Suppression{Email: "user@example.com", Reason: SuppressionReasonManual, Source: source}
+	if err := SaveSuppression(db, s); err != nil {
+		t.Fatalf("SaveSuppression() 에러 = %v", err)
+	}
+	return s
+}
+
+// loadTestSuppression 해제된 항목을 포함하여 수신 거부 재조회
+func loadTestSuppression(t *testing.T, db *gorm.DB, id uint) Suppression {
+	t.Helper()
+	var s Suppression
+	if err := db.Unscoped().First(&s, id).Error; err != nil {
+		t.Fatalf("Suppression 조회 실패: %v", err)
+	}
+	return s
+}
+
+// TestMarkSESSynced SES에 반영하는 동안 항목이 바뀌면 반영 완료로 기록하지 않음
+func TestMarkSESSynced(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name       string                          // 테스트 케이스 이름
+		change     func(t *testing.T, db *gorm.DB) // 조회 이후 반영 전 변경 (nil이면 없음)
+		wantSynced bool                            // 반영 완료 기록 여부
+	}{
+		{name: "변경 없음", wantSynced: true},
+		{
+			name: "반영 중 해제",
+			change: func(t *testing.T, db *gorm.DB) {
+				if err := RemoveSuppression(db, "user@example.com"); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "반영 중 다시 저장",
+			change: func(t *testing.T, db *gorm.DB) {
+				saveTestSuppression(t, db, SuppressionSourceImport)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTemplateTestDB(t)
+			snapshot := loadTestSuppression(t, db, saveTestSuppression(t, db, SuppressionSourceAPI).ID)
+			if tt.change != nil {
+				tt.change(t, db)
+			}
+
+			synced, err := MarkSESSynced(db, &snapshot, now)
+			if err != nil {
+				t.Fatalf("MarkSESSynced() 에러 = %v", err)
+			}
+			if synced != tt.wantSynced {
+				t.Errorf("synced = %v, 예상 = %v", synced, tt.wantSynced)
+			}
+			if saved := loadTestSuppression(t, db, snapshot.ID); (saved.SesSyncedAt != nil) != tt.wantSynced {
+				t.Errorf("SesSyncedAt = %v, 반영 완료 예상 = %v", saved.SesSyncedAt, tt.wantSynced)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SyncState 외부 서비스와의 주기적 동기화 진행 상태 (동기화 작업별 하나)
+type SyncState struct {
+	gorm.Model
+	Name      string     `json:"name" gorm:"not null;type:varchar(100);uniqueIndex:idx_sync_state_name"`
+	Cursor    *time.Time `json:"cursor"`                      // 다음 증분 동기화의 시작 시각 (마지막으로 성공한 동기화 시작 시각)
+	LastRunAt *time.Time `json:"last_run_at"`                 // 마지막 실행 시각
+	LastError string     `json:"last_error" gorm:"type:text"` // 마지막 실행 실패 사유 (성공하면 비움)
+}
+
+func (SyncState) TableName() string {
+	return "sync_states"
+}
+
+// GetSyncState 동기화 상태 조회 (없으면 빈 상태 반환)
+func GetSyncState(db *gorm.DB, name string) (*SyncState, error) {
+	state := &SyncState{}
+	if err := db.Where(SyncState{Name: name}).FirstOrInit(state).Error; err != nil {
+		return nil, fmt.Errorf("failed to find sync state %s: %w", name, err)
+	}
+	return state, nil
+}
+
+// SaveSyncState 동기화 상태 저장 (없으면 생성)
+func SaveSyncState(db *gorm.DB, name string, update func(state *SyncState)) (*SyncState, error) {
+	state, err := GetSyncState(db, name)
+	if err != nil {
+		return nil, err
+	}
+	update(state)
+	if err := db.Save(state).Error; err != nil {
+		return nil, fmt.Errorf("failed to save sync state %s: %w", name, err)
+	}
+	return state, nil
+}
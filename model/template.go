@@ -0,0 +1,232 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrTemplateNotFound 템플릿이 없는 경우
+var ErrTemplateNotFound = errors.New("template not found")
+
+// ErrTemplateExists 같은 이름의 템플릿이 이미 있는 경우
+var ErrTemplateExists = errors.New("template already exists")
+
+// TemplateVariable 템플릿 변수 정의
+type TemplateVariable struct {
+	Name        string `json:"name"`
+	Required    bool   `json:"required"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Template 저장된 이메일 템플릿 (버전별 불변, 수정 시 새 버전 발행)
+type Template struct {
+	gorm.Model
+	Name      string `json:"name" gorm:"not null;type:varchar(100);uniqueIndex:idx_template_name_version"`
+	Version   int    `json:"version" gorm:"not null;uniqueIndex:idx_template_name_version"`
+	Subject   string `json:"subject" gorm:"not null;type:varchar(255)"`
+	HTML      string `json:"html" gorm:"not null;type:text"`
+	Text      string `json:"text" gorm:"type:text"`
+	Variables string `json:"variables" gorm:"type:json"`
+}
+
+func (Template) TableName() string {
+	return "email_templates"
+}
+
+// Validate 템플릿 필드 검증
+func (t *Template) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if strings.ContainsAny(t.Name, "/?#") {
+		return fmt.Errorf("template name cannot contain '/', '?' or '#'")
+	}
+	if strings.TrimSpace(t.Subject) == "" {
+		return fmt.Errorf("subject cannot be empty")
+	}
+	if strings.TrimSpace(t.HTML) == "" {
+		return fmt.Errorf("html cannot be empty")
+	}
+	if _, err := t.VariableList(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// VariableList 변수 정의 목록 조회
+func (t *Template) VariableList() ([]TemplateVariable, error) {
+	if t.Variables == "" {
+		return nil, nil
+	}
+	var vars []TemplateVariable
+	if err := json.Unmarshal([]byte(t.Variables), &vars); err != nil {
+		return nil, fmt.Errorf("invalid template variables: %w", err)
+	}
+	for _, v := range vars {
+		if v.Name == "" {
+			return nil, fmt.Errorf("template variable name is required")
+		}
+	}
+	return vars, nil
+}
+
+// DefaultVars 변수 정의의 기본값 목록
+func (t *Template) DefaultVars() map[string]string {
+	vars, _ := t.VariableList()
+	defaults := make(map[string]string)
+	for _, v := range vars {
+		if v.Default != "" {
+			defaults[v.Name] = v.Default
+		}
+	}
+	return defaults
+}
+
+// MissingVars 기본값이 없는 필수 변수 중 주어진 변수에 없는 항목
+func (t *Template) MissingVars(vars map[string]string) []string {
+	defs, _ := t.VariableList()
+	var missing []string
+	for _, v := range defs {
+		if !v.Required || v.Default != "" {
+			continue
+		}
+		if _, ok := vars[v.Name]; !ok {
+			missing = append(missing, v.Name)
+		}
+	}
+	return missing
+}
+
+// CreateTemplate 새 템플릿 생성 (삭제된 같은 이름의 템플릿이 있으면 이어지는 버전으로 생성)
+func CreateTemplate(db *gorm.DB, tpl *Template) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		if err := tx.Model(&Template{}).Where("name = ?", tpl.Name).Count(&cnt).Error; err != nil {
+			return fmt.Errorf("failed to check template: %w", err)
+		}
+		if cnt > 0 {
+			return ErrTemplateExists
+		}
+		return createTemplateVersion(tx, tpl)
+	})
+}
+
+// PublishTemplateVersion 기존 템플릿의 새 버전 발행 (이전 버전은 변경하지 않음)
+func PublishTemplateVersion(db *gorm.DB, tpl *Template) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		if err := tx.Model(&Template{}).Where("name = ?", tpl.Name).Count(&cnt).Error; err != nil {
+			return fmt.Errorf("failed to check template: %w", err)
+		}
+		if cnt == 0 {
+			return ErrTemplateNotFound
+		}
+		return createTemplateVersion(tx, tpl)
+	})
+}
+
+// createTemplateVersion 다음 버전 번호로 템플릿 저장 (삭제된 버전 번호도 재사용하지 않음)
+func createTemplateVersion(tx *gorm.DB, tpl *Template) error {
+	var latest int
+	err := tx.Unscoped().Model(&Template{}).Where("name = ?", tpl.Name).
+		Select("COALESCE(MAX(version), 0)").Scan(&latest).Error
+	if err != nil {
+		return fmt.Errorf("failed to find template version: %w", err)
+	}
+	tpl.ID = 0
+	tpl.Version = latest + 1
+	if err := tx.Create(tpl).Error; err != nil {
+		return fmt.Errorf("failed to create template version: %w", err)
+	}
+	return nil
+}
+
+// FindTemplate 템플릿 조회 (version이 0이면 최신 버전)
+func FindTemplate(db *gorm.DB, name string, version int) (*Template, error) {
+	query := db.Where("name = ?", name)
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	} else {
+		query = query.Order("version DESC")
+	}
+
+	tpl := &Template{}
+	if err := query.First(tpl).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to find template: %w", err)
+	}
+	return tpl, nil
+}
+
+// ListTemplates 템플릿별 최신 버전 목록
+func ListTemplates(db *gorm.DB) ([]Template, error) {
+	var templates []Template
+	err := db.Where("id IN (?)",
+		db.Model(&Template{}).Select("MAX(id)").Group("name"),
+	).Order("name ASC").Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return templates, nil
+}
+
+// ListTemplateVersions 템플릿의 전체 버전 목록 (최신순)
+func ListTemplateVersions(db *gorm.DB, name string) ([]Template, error) {
+	var versions []Template
+	if err := db.Where("name = ?", name).Order("version DESC").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list template versions: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, ErrTemplateNotFound
+	}
+	return versions, nil
+}
+
+// DeleteTemplate 템플릿의 전체 버전 삭제 (이미 생성된 발송 요청에는 영향 없음)
+func DeleteTemplate(db *gorm.DB, name string) error {
+	result := db.Where("name = ?", name).Delete(&Template{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// TemplateContent 템플릿 버전의 발송 컨텐츠 조회 또는 생성 (버전과 발송 옵션이 같으면 재사용)
+// opts의 DefaultVars, Strict, NoClickTracking을 발송 옵션으로 사용
+// 컨텐츠는 템플릿 내용을 복사하여 저장하므로 새 버전이 발행되어도 기존 발송 요청은 변경되지 않음
+func TemplateContent(tx *gorm.DB, tpl *Template, opts Content) (*Content, error) {
+	content := &Content{}
+	err := tx.Where("template_id = ? AND default_vars = ? AND strict = ? AND no_click_tracking = ?",
+		tpl.ID, opts.DefaultVars, opts.Strict, opts.NoClickTracking).
+		First(content).Error
+	if err == nil {
+		return content, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find template content: %w", err)
+	}
+
+	content = &Content{
+		Subject:         tpl.Subject,
+		Content:         tpl.HTML,
+		Text:            tpl.Text,
+		DefaultVars:     opts.DefaultVars,
+		Strict:          opts.Strict,
+		NoClickTracking: opts.NoClickTracking,
+		TemplateId:      &tpl.ID,
+	}
+	if err := tx.Create(content).Error; err != nil {
+		return nil, fmt.Errorf("failed to create template content: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTemplateTestDB 테스트용 인메모리 DB 생성
+func newTemplateTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("테스트 DB 생성 실패: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("테스트 DB 인스턴스 조회 실패: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := AutoMigrate(db); err != nil {
+		t.Fatalf("테스트 DB 마이그레이션 실패: %v", err)
+	}
+	return db
+}
+
+// TestTemplateValidate 템플릿 필드 검증 테스트
+func TestTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name    string    // 테스트 케이스 이름
+		tpl     *Template // 검증할 템플릿
+		wantErr bool      // 에러 발생 예상 여부
+	}{
+		{"정상 템플릿", &Template{Name: "welcome", Subject: "환영합니다", HTML: "<p>hi</p>"}, false},
+		{"변수 정의 포함", &Template{Name: "welcome", Subject: "s", HTML: "h", Variables: `[{"name":"name","required":true}]`}, false},
+		{"이름 없음", &Template{Subject: "s", HTML: "h"}, true},
+		{"이름에 경로 문자 포함", &Template{Name: "a/b", Subject: "s", HTML: "h"}, true},
+		{"제목 없음", &Template{Name: "welcome", HTML: "h"}, true},
+		{"본문 없음", &Template{Name: "welcome", Subject: "s"}, true},
+		{"잘못된 변수 정의", &Template{Name: "welcome", Subject: "s", HTML: "h", Variables: `{`}, true},
+		{"변수 이름 없음", &Template{Name: "welcome", Subject: "s", HTML: "h", Variables: `[{"required":true}]`}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tpl.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() 에러 = %v, 예상 에러 여부 = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestTemplateVars 변수 기본값과 누락된 필수 변수 조회 테스트
+func TestTemplateVars(t *testing.T) {
+	tpl := &Template{Variables: `[
+		{"name":"name","required":true},
+		{"name":"plan","required":true,"default":"free"},
+		{"name":"coupon"}
+	]`}
+
+	if got, want := tpl.DefaultVars(), map[string]string{"plan": "free"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultVars() = %v, 예상 = %v", got, want)
+	}
+	if got := tpl.MissingVars(map[string]string{}); !reflect.DeepEqual(got, []string{"name"}) {
+		t.Errorf("MissingVars() = %v, 예상 = [name]", got)
+	}
+	if got := tpl.MissingVars(map[string]string{"name": "홍길동"}); len(got) != 0 {
+		t.Errorf("MissingVars() = %v, 누락 변수가 없어야 함", got)
+	}
+}
+
+// TestTemplateVersions 템플릿 생성, 버전 발행, 조회, 삭제 테스트
+func TestTemplateVersions(t *testing.T) {
+	db := newTemplateTestDB(t)
+
+	v1 := &Template{Name: "welcome", Subject: "v1 제목", HTML: "<p>v1</p>"}
+	if err := CreateTemplate(db, v1); err != nil {
+		t.Fatalf("템플릿 생성 실패: %v", err)
+	}
+	if v1.Version != 1 {
+		t.Errorf("첫 버전 = %d, 예상 = 1", v1.Version)
+	}
+	if err := CreateTemplate(db, &Template{Name: "welcome", Subject: "s", HTML: "h"}); !errors.Is(err, ErrTemplateExists) {
+		t.Errorf("중복 생성 에러 = %v, 예상 = %v", err, ErrTemplateExists)
+	}
+	if err := PublishTemplateVersion(db, &Template{Name: "unknown", Subject: "s", HTML: "h"}); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("없는 템플릿 발행 에러 = %v, 예상 = %v", err, ErrTemplateNotFound)
+	}
+
+	v2 := &Template{Name: "welcome", Subject: "v2 제목", HTML: "<p>v2</p>"}
+	if err := PublishTemplateVersion(db, v2); err != nil {
+		t.Fatalf("새 버전 발행 실패: %v", err)
+	}
+	if v2.Version != 2 {
+		t.Errorf("발행 버전 = %d, 예상 = 2", v2.Version)
+	}
+
+	latest, err := FindTemplate(db, "welcome", 0)
+	if err != nil || latest.Version != 2 {
+		t.Fatalf("최신 버전 조회 = %+v, %v, 예상 버전 = 2", latest, err)
+	}
+	old, err := FindTemplate(db, "welcome", 1)
+	if err != nil || old.Subject != "v1 제목" {
+		t.Fatalf("이전 버전 조회 = %+v, %v, 이전 버전은 변경되지 않아야 함", old, err)
+	}
+	if _, err := FindTemplate(db, "welcome", 3); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("없는 버전 조회 에러 = %v, 예상 = %v", err, ErrTemplateNotFound)
+	}
+
+	if err := CreateTemplate(db, &Template{Name: "receipt", Subject: "s", HTML: "h"}); err != nil {
+		t.Fatalf("템플릿 생성 실패: %v", err)
+	}
+	list, err := ListTemplates(db)
+	if err != nil {
+		t.Fatalf("템플릿 목록 조회 실패: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "receipt" || list[1].Name != "welcome" || list[1].Version != 2 {
+		t.Errorf("템플릿 목록 = %+v, 템플릿별 최신 버전만 포함되어야 함", list)
+	}
+
+	if err := DeleteTemplate(db, "welcome"); err != nil {
+		t.Fatalf("템플릿 삭제 실패: %v", err)
+	}
+	if _, err := FindTemplate(db, "welcome", 0); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("삭제 후 조회 에러 = %v, 예상 = %v", err, ErrTemplateNotFound)
+	}
+	if err := DeleteTemplate(db, "welcome"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("중복 삭제 에러 = %v, 예상 = %v", err, ErrTemplateNotFound)
+	}
+
+	// 삭제 후 다시 생성하면 이전 버전 번호를 재사용하지 않음
+	recreated := &Template{Name: "welcome", Subject: "s", HTML: "h"}
+	if err := CreateTemplate(db, recreated); err != nil {
+		t.Fatalf("삭제 후 재생성 실패: %v", err)
+	}
+	if recreated.Version != 3 {
+		t.Errorf("재생성 버전 = %d, 예상 = 3", recreated.Version)
+	}
+}
+
+// TestTemplateContent 템플릿 버전별 발송 컨텐츠 재사용 테스트
+func TestTemplateContent(t *testing.T) {
+	db := newTemplateTestDB(t)
+
+	v1 := &Template{Name: "welcome", Subject: "v1 제목", HTML: "<p>v1</p>", Text: "v1"}
+	if err := CreateTemplate(db, v1); err != nil {
+		t.Fatalf("템플릿 생성 실패: %v", err)
+	}
+	first, err := TemplateContent(db, v1, Content{DefaultVars: `{"name":"a"}`})
+	if err != nil {
+		t.Fatalf("컨텐츠 생성 실패: %v", err)
+	}
+	if first.Subject != v1.Subject || first.Content != v1.HTML || first.Text != v1.Text {
+		t.Errorf("컨텐츠 = %+v, 템플릿 내용이 복사되어야 함", first)
+	}
+
+	again, err := TemplateContent(db, v1, Content{DefaultVars: `{"name":"a"}`})
+	if err != nil || again.ID != first.ID {
+		t.Errorf("같은 조건의 컨텐츠 ID = %d, 예상 = %d (%v)", again.ID, first.ID, err)
+	}
+	strict, err := TemplateContent(db, v1, Content{DefaultVars: `{"name":"a"}`, Strict: true})
+	if err != nil || strict.ID == first.ID {
+		t.Errorf("엄격 모드 컨텐츠가 기존 컨텐츠를 재사용함 (ID=%d, %v)", strict.ID, err)
+	}
+
+	// 새 버전을 발행해도 기존 컨텐츠는 변경되지 않음
+	v2 := &Template{Name: "welcome", Subject: "v2 제목", HTML: "<p>v2</p>"}
+	if err := PublishTemplateVersion(db, v2); err != nil {
+		t.Fatalf("새 버전 발행 실패: %v", err)
+	}
+	next, err := TemplateContent(db, v2, Content{DefaultVars: `{"name":"a"}`})
+	if err != nil || next.ID == first.ID || next.Subject != "v2 제목" {
+		t.Errorf("새 버전 컨텐츠 = %+v (%v), 별도 컨텐츠가 생성되어야 함", next, err)
+	}
+	var stored Content
+	if err := db.First(&stored, first.ID).Error; err != nil {
+		t.Fatalf("기존 컨텐츠 조회 실패: %v", err)
+	}
+	if stored.Subject != "v1 제목" {
+		t.Errorf("기존 컨텐츠 제목 = %q, 예상 = %q", stored.Subject, "v1 제목")
+	}
+}
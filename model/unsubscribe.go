@@ -0,0 +1,89 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 수신 거부 범위
+const (
+	UnsubscribeScopeTopic  = "topic"  // 수신 거부한 메일의 토픽만
+	UnsubscribeScopeGlobal = "global" // 모든 토픽
+)
+
+// 수신 거부 방법
+const (
+	UnsubscribeMethodOneClick = "one-click" // 메일 클라이언트의 원클릭 수신 거부 (RFC 8058)
+	UnsubscribeMethodPage     = "page"      // 수신 거부 안내 페이지
+)
+
+// Unsubscribe 수신자가 직접 수신 거부한 주소 (주소와 토픽별 하나, 토픽이 비어 있으면 모든 토픽)
+type Unsubscribe struct {
+	gorm.Model
+	Email     string `json:"email" gorm:"not null;type:varchar(255);uniqueIndex:idx_unsubscribe_email_topic"`
+	TopicId   string `json:"topic_id" gorm:"not null;default:'';type:varchar(50);uniqueIndex:idx_unsubscribe_email_topic"`
+	RequestId *uint  `json:"request_id"`                              // 수신 거부 링크가 포함된 발송 요청
+	Method    string `json:"method" gorm:"not null;type:varchar(20)"` // one-click, page
+}
+
+func (Unsubscribe) TableName() string {
+	return "email_unsubscribes"
+}
+
+// Scope 수신 거부 범위 (topic, global)
+func (u *Unsubscribe) Scope() string {
+	if u.TopicId == "" {
+		return UnsubscribeScopeGlobal
+	}
+	return UnsubscribeScopeTopic
+}
+
+// SaveUnsubscribe 수신 거부 저장 (같은 주소와 토픽이 이미 있으면 마지막 요청과 방법만 갱신)
+func SaveUnsubscribe(db *gorm.DB, u *Unsubscribe) error {
+	u.Email = NormalizeEmail(u.Email)
+	if u.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	u.TopicId = strings.TrimSpace(u.TopicId)
+
+	// 구조체 조건은 빈 토픽(모든 토픽)을 무시하므로 컬럼 조건으로 조회
+	err := db.Where("email = ? AND topic_id = ?", u.Email, u.TopicId).
+		Assign(map[string]interface{}{"request_id": u.RequestId, "method": u.Method}).
+		FirstOrCreate(u).Error
+	if err != nil {
+		return fmt.Errorf("failed to save unsubscribe %s: %w", u.Email, err)
+	}
+	return nil
+}
+
+// UnsubscribedTopics 주소 목록 중 수신 거부한 주소별 토픽 집합 (정규화된 주소별, 빈 토픽은 모든 토픽)
+func UnsubscribedTopics(db *gorm.DB, emails []string) (map[string]map[string]bool, error) {
+	found := make(map[string]map[string]bool)
+	if len(emails) == 0 {
+		return found, nil
+	}
+
+	normalized := make([]string, 0, len(emails))
+	for _, e := range emails {
+		normalized = append(normalized, NormalizeEmail(e))
+	}
+
+	var list []Unsubscribe
+	if err := db.Where("email IN ?", normalized).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to check unsubscribes: %w", err)
+	}
+	for _, u := range list {
+		if found[u.Email] == nil {
+			found[u.Email] = make(map[string]bool)
+		}
+		found[u.Email][u.TopicId] = true
+	}
+	return found, nil
+}
+
+// IsUnsubscribed 수신 거부 토픽 집합이 해당 토픽의 발송을 막는지 여부 (모든 토픽 수신 거부 포함)
+func IsUnsubscribed(topics map[string]bool, topicId string) bool {
+	return topics[""] || topics[topicId]
+}
@@ -0,0 +1,41 @@
+package model
+
+import "testing"
+
+// TestSaveUnsubscribe 주소와 토픽별 수신 거부 저장 및 조회 테스트
+func TestSaveUnsubscribe(t *testing.T) {
+	db := newTemplateTestDB(t)
+	first, second := uint(1), uint(2)
+
+	for _, u := range []*Unsubscribe{
+		{Email: " User@Example.com", TopicId: "newsletter", RequestId: &first, Method: UnsubscribeMethodOneClick},
+		{Email: "user@example.com", TopicId: "newsletter", RequestId: &second, Method: UnsubscribeMethodPage},
+		{Email: "user@example.com", Method: UnsubscribeMethodPage},
+	} {
+		if err := SaveUnsubscribe(db, u); err != nil {
+			t.Fatalf("SaveUnsubscribe() 에러 = %v", err)
+		}
+	}
+
+	var count int64
+	db.Model(&Unsubscribe{}).Count(&count)
+	if count != 2 {
+		t.Errorf("저장된 수신 거부 수 = %d, 예상 = 2 (같은 토픽은 갱신)", count)
+	}
+	var topic Unsubscribe
+	db.Where("topic_id = ?", "newsletter").Take(&topic)
+	if topic.RequestId == nil || *topic.RequestId != second || topic.Method != UnsubscribeMethodPage {
+		t.Errorf("갱신된 수신 거부 = %+v", topic)
+	}
+
+	topics, err := UnsubscribedTopics(db, []string{"USER@example.com", "other@example.com"})
+	if err != nil {
+		t.Fatalf("UnsubscribedTopics() 에러 = %v", err)
+	}
+	if len(topics) != 1 || !topics["user@example.com"]["newsletter"] || !topics["user@example.com"][""] {
+		t.Errorf("UnsubscribedTopics() = %v", topics)
+	}
+	if !IsUnsubscribed(map[string]bool{"": true}, "billing") || IsUnsubscribed(map[string]bool{"newsletter": true}, "billing") {
+		t.Error("IsUnsubscribed() 전체/토픽 수신 거부 판정 오류")
+	}
+}
@@ -2,9 +2,12 @@ package aws
 
 import (
 	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/pkg/mailer"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"strconv"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -12,6 +15,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/sesv2"
 	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
 // SES AWS SES 클라이언트 래퍼
@@ -23,15 +28,31 @@ type SES struct {
 
 // NewSESClient SES 클라이언트 생성
 func NewSESClient(ctx context.Context) (*SES, error) {
-	region := config.GetEnv("AWS_REGION", "ap-northeast-2")
-	accessKeyID := config.GetEnv("AWS_ACCESS_KEY_ID")
-	secretAccessKey := config.GetEnv("AWS_SECRET_ACCESS_KEY")
 	senderEmail := config.GetEnv("EMAIL_SENDER")
-
 	if senderEmail == "" {
 		return nil, fmt.Errorf("EMAIL_SENDER environment variable is required")
 	}
 
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("SES client initialized (region=%s, sender=%s)", cfg.Region, senderEmail)
+
+	return &SES{
+		Client:        sesv2.NewFromConfig(cfg),
+		senderEmail:   senderEmail,
+		configSetName: config.GetEnv("SES_CONFIG_SET", ""),
+	}, nil
+}
+
+// loadConfig AWS 설정 로드 (AWS_REGION, 액세스 키가 없으면 기본 자격 증명 체인 사용)
+func loadConfig(ctx context.Context) (aws.Config, error) {
+	region := config.GetEnv("AWS_REGION", "ap-northeast-2")
+	accessKeyID := config.GetEnv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := config.GetEnv("AWS_SECRET_ACCESS_KEY")
+
 	var cfgOpts []func(*awsConfig.LoadOptions) error
 	cfgOpts = append(cfgOpts, awsConfig.WithRegion(region))
 
@@ -43,69 +64,151 @@ func NewSESClient(ctx context.Context) (*SES, error) {
 
 	cfg, err := awsConfig.LoadDefaultConfig(ctx, cfgOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
 	}
-
-	log.Printf("SES client initialized (region=%s, sender=%s)", region, senderEmail)
-
-	return &SES{
-		Client:        sesv2.NewFromConfig(cfg),
-		senderEmail:   senderEmail,
-		configSetName: config.GetEnv("SES_CONFIG_SET", ""),
-	}, nil
+	return cfg, nil
 }
 
 // SendEmail AWS SES를 통한 이메일 발송
 func (s *SES) SendEmail(ctx context.Context, reqID int, subject, body *string, receivers []string) (string, error) {
-	if subject == nil || *subject == "" {
-		return "", fmt.Errorf("subject cannot be empty")
+	msg := &mailer.Message{
+		RequestID: uint(reqID),
+		To:        receivers,
 	}
-	if body == nil || *body == "" {
-		return "", fmt.Errorf("body cannot be empty")
+	if subject != nil {
+		msg.Subject = *subject
 	}
-	if len(receivers) == 0 {
-		return "", fmt.Errorf("receivers list cannot be empty")
+	if body != nil {
+		msg.HTML = *body
+	}
+	return s.Send(ctx, msg)
+}
+
+// Send mailer.Mailer 구현 (SES 에러를 일시적/영구 에러로 분류하여 반환)
+func (s *SES) Send(ctx context.Context, msg *mailer.Message) (string, error) {
+	if err := msg.Validate(); err != nil {
+		return "", mailer.Permanent(err)
 	}
 
 	input := &sesv2.SendEmailInput{
 		FromEmailAddress: aws.String(s.senderEmail),
 		Destination: &types.Destination{
-			ToAddresses: receivers,
+			ToAddresses: msg.To,
 		},
 		Content: &types.EmailContent{
 			Simple: &types.Message{
 				Subject: &types.Content{
-					Data:    aws.String(*subject),
+					Data:    aws.String(msg.Subject),
 					Charset: aws.String("UTF-8"),
 				},
 				Body: &types.Body{
 					Html: &types.Content{
-						Data:    aws.String(*body),
+						Data:    aws.String(msg.HTML),
 						Charset: aws.String("UTF-8"),
 					},
 				},
 				Headers: []types.MessageHeader{
 					{
 						Name:  aws.String("X-Request-ID"),
-						Value: aws.String(strconv.Itoa(reqID)),
+						Value: aws.String(strconv.FormatUint(uint64(msg.RequestID), 10)),
 					},
 				},
 			},
 		},
 	}
 
+	for _, h := range msg.UnsubscribeHeaders() {
+		input.Content.Simple.Headers = append(input.Content.Simple.Headers, types.MessageHeader{
+			Name:  aws.String(h[0]),
+			Value: aws.String(h[1]),
+		})
+	}
+
+	if msg.Text != "" {
+		input.Content.Simple.Body.Text = &types.Content{
+			Data:    aws.String(msg.Text),
+			Charset: aws.String("UTF-8"),
+		}
+	}
+
 	if s.configSetName != "" {
 		input.ConfigurationSetName = aws.String(s.configSetName)
 	}
 
 	result, err := s.Client.SendEmail(ctx, input)
 	if err != nil {
-		return "", fmt.Errorf("failed to send email via SES: %w", err)
+		return "", classifyError(fmt.Errorf("failed to send email via SES: %w", err))
 	}
 
 	if result.MessageId == nil {
-		return "", fmt.Errorf("SES returned nil message ID")
+		return "", mailer.Permanent(fmt.Errorf("SES returned nil message ID"))
 	}
 
 	return *result.MessageId, nil
 }
+
+// Quota mailer.QuotaProvider 구현 (SES 계정 발송 한도 조회)
+func (s *SES) Quota(ctx context.Context) (*mailer.Quota, error) {
+	result, err := s.Client.GetAccount(ctx, &sesv2.GetAccountInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SES account: %w", err)
+	}
+	if result.SendQuota == nil {
+		return nil, fmt.Errorf("SES returned no send quota")
+	}
+	return &mailer.Quota{
+		MaxSendRate:     result.SendQuota.MaxSendRate,
+		Max24HourSend:   result.SendQuota.Max24HourSend,
+		SentLast24Hours: result.SendQuota.SentLast24Hours,
+	}, nil
+}
+
+// throttlingErrorCodes 발송 속도 제한 초과를 나타내는 SES 에러 코드
+var throttlingErrorCodes = map[string]bool{
+	"TooManyRequestsException": true,
+	"ThrottlingException":      true,
+	"Throttling":               true,
+}
+
+// transientErrorCodes 재시도 가능한 SES 에러 코드
+var transientErrorCodes = map[string]bool{
+	"TooManyRequestsException": true,
+	"LimitExceededException":   true,
+	"ThrottlingException":      true,
+	"Throttling":               true,
+	"SendingPausedException":   true,
+	"ServiceUnavailable":       true,
+	"InternalFailure":          true,
+	"InternalServerError":      true,
+	"RequestTimeout":           true,
+}
+
+// classifyError SES 호출 에러를 일시적/영구 에러로 분류
+func classifyError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return mailer.Transient(err)
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		if throttlingErrorCodes[apiErr.ErrorCode()] {
+			return mailer.Throttled(err)
+		}
+		if transientErrorCodes[apiErr.ErrorCode()] {
+			return mailer.Transient(err)
+		}
+		return mailer.Permanent(err)
+	}
+
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() >= 500 {
+		return mailer.Transient(err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return mailer.Transient(err)
+	}
+
+	return mailer.Permanent(err)
+}
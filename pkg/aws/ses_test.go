@@ -1,8 +1,19 @@
 package aws
 
 import (
+	"aws-ses-sender-go/pkg/mailer"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/smithy-go"
 )
 
 // TestSendEmailValidation SendEmail 함수의 입력 검증 테스트
@@ -266,3 +277,189 @@ func TestSendEmailSpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+// TestClassifyError SES 에러 분류 테스트
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name          string // 테스트 케이스 이름
+		err           error  // 분류할 에러
+		wantTransient bool   // 일시적 에러 여부
+	}{
+		{
+			name:          "TooManyRequestsException은 일시적 에러",
+			err:           &smithy.GenericAPIError{Code: "TooManyRequestsException"},
+			wantTransient: true,
+		},
+		{
+			name:          "LimitExceededException은 일시적 에러",
+			err:           &smithy.GenericAPIError{Code: "LimitExceededException"},
+			wantTransient: true,
+		},
+		{
+			name:          "MessageRejected는 영구 에러",
+			err:           &smithy.GenericAPIError{Code: "MessageRejected"},
+			wantTransient: false,
+		},
+		{
+			name:          "타임아웃은 일시적 에러",
+			err:           fmt.Errorf("send: %w", context.DeadlineExceeded),
+			wantTransient: true,
+		},
+		{
+			name:          "알 수 없는 에러는 영구 에러",
+			err:           errors.New("unknown"),
+			wantTransient: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyError(tt.err)
+			if got := mailer.IsTransient(err); got != tt.wantTransient {
+				t.Errorf("IsTransient(classifyError()) = %v, 예상 = %v", got, tt.wantTransient)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("분류된 에러가 원본 에러를 래핑하지 않음")
+			}
+		})
+	}
+}
+
+// TestSendValidationIsPermanent 입력 검증 실패는 영구 에러로 분류
+func TestSendValidationIsPermanent(t *testing.T) {
+	ses := &SES{senderEmail: "sender@example.com"}
+
+	_, err := ses.Send(context.Background(), &mailer.Message{To: []string{"a@example.com"}})
+	if err == nil {
+		t.Fatal("에러를 예상했지만 nil이 반환됨")
+	}
+	if mailer.IsTransient(err) {
+		t.Error("입력 검증 에러가 일시적 에러로 분류됨")
+	}
+}
+
+// newFakeSES 가짜 SES 엔드포인트를 사용하는 테스트용 클라이언트 생성
+func newFakeSES(t *testing.T, handler http.HandlerFunc) *SES {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := sesv2.New(sesv2.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(srv.URL),
+		Credentials:      credentials.NewStaticCredentialsProvider("test", "test", ""),
+		RetryMaxAttempts: 1,
+	})
+	return &SES{Client: client, senderEmail: "sender@example.com"}
+}
+
+// TestQuota GetAccount 응답의 발송 한도 변환 테스트
+func TestQuota(t *testing.T) {
+	ses := newFakeSES(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/email/account" {
+			t.Errorf("예상치 못한 요청: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"SendQuota":{"Max24HourSend":50000,"MaxSendRate":14,"SentLast24Hours":1200},"SendingEnabled":true}`)
+	})
+
+	quota, err := ses.Quota(context.Background())
+	if err != nil {
+		t.Fatalf("Quota() 에러 = %v", err)
+	}
+	want := mailer.Quota{MaxSendRate: 14, Max24HourSend: 50000, SentLast24Hours: 1200}
+	if *quota != want {
+		t.Errorf("Quota() = %+v, 예상 = %+v", *quota, want)
+	}
+}
+
+// TestSendThrottled SES 속도 제한 응답이 속도 제한 에러로 분류되는지 검증
+func TestSendThrottled(t *testing.T) {
+	ses := newFakeSES(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Amzn-Errortype", "TooManyRequestsException")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"message":"Maximum sending rate exceeded."}`)
+	})
+
+	_, err := ses.Send(context.Background(), &mailer.Message{
+		RequestID: 1,
+		To:        []string{"a@example.com"},
+		Subject:   "subject",
+		HTML:      "<p>body</p>",
+	})
+	if err == nil {
+		t.Fatal("에러를 예상했지만 nil이 반환됨")
+	}
+	if !mailer.IsThrottled(err) {
+		t.Errorf("IsThrottled() = false, 예상 = true (err=%v)", err)
+	}
+	if !mailer.IsTransient(err) {
+		t.Error("속도 제한 에러가 일시적 에러로 분류되지 않음")
+	}
+}
+
+// TestSendUnsubscribeHeaders 수신 거부 URL이 있으면 List-Unsubscribe 헤더를 함께 전달하는지 검증
+func TestSendUnsubscribeHeaders(t *testing.T) {
+	tests := []struct {
+		name           string   // 테스트 케이스 이름
+		unsubscribeURL string   // 수신 거부 URL
+		wantHeaders    []string // 예상 헤더 이름 목록
+	}{
+		{
+			name:           "수신 거부 URL 있음",
+			unsubscribeURL: "https://mail.example.com/v1/unsubscribe?requestId=7&sig=abc",
+			wantHeaders:    []string{"X-Request-ID", "List-Unsubscribe", "List-Unsubscribe-Post"},
+		},
+		{
+			name:        "수신 거부 URL 없음",
+			wantHeaders: []string{"X-Request-ID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var headers []struct{ Name, Value string }
+			ses := newFakeSES(t, func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Content struct {
+						Simple struct {
+							Headers []struct{ Name, Value string }
+						}
+					}
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("요청 본문 파싱 실패: %v", err)
+				}
+				headers = body.Content.Simple.Headers
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"MessageId":"m-1"}`)
+			})
+
+			_, err := ses.Send(context.Background(), &mailer.Message{
+				RequestID:      7,
+				To:             []string{"a@example.com"},
+				Subject:        "subject",
+				HTML:           "<p>body</p>",
+				UnsubscribeURL: tt.unsubscribeURL,
+			})
+			if err != nil {
+				t.Fatalf("Send() 에러 = %v", err)
+			}
+
+			if len(headers) != len(tt.wantHeaders) {
+				t.Fatalf("헤더 = %+v, 예상 이름 = %v", headers, tt.wantHeaders)
+			}
+			for i, name := range tt.wantHeaders {
+				if headers[i].Name != name {
+					t.Errorf("헤더[%d] = %s, 예상 = %s", i, headers[i].Name, name)
+				}
+			}
+			if tt.unsubscribeURL != "" {
+				if headers[1].Value != "<"+tt.unsubscribeURL+">" || headers[2].Value != "List-Unsubscribe=One-Click" {
+					t.Errorf("수신 거부 헤더 값 = %q, %q", headers[1].Value, headers[2].Value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"aws-ses-sender-go/config"
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// QueueMessage 대기열에서 받은 메시지
+type QueueMessage struct {
+	MessageId     string
+	ReceiptHandle string
+	Body          string
+}
+
+// SQS AWS SQS 대기열 클라이언트 래퍼
+type SQS struct {
+	Client      *sqs.Client
+	queueURL    string
+	waitSeconds int32
+	maxMessages int32
+}
+
+// NewSQSClient SQS 대기열 클라이언트 생성
+// SQS_ENDPOINT를 지정하면 해당 주소로 요청 (ElasticMQ, LocalStack 등 로컬 호환 서버)
+func NewSQSClient(ctx context.Context, queueURL string) (*SQS, error) {
+	if queueURL == "" {
+		return nil, fmt.Errorf("SQS queue URL is required")
+	}
+
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := config.GetEnv("SQS_ENDPOINT")
+	client := sqs.NewFromConfig(cfg, func(o *sqs.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+
+	waitSeconds := config.GetEnvAsInt("SQS_WAIT_SECONDS", 20)
+	if waitSeconds < 0 || waitSeconds > 20 {
+		waitSeconds = 20
+	}
+	maxMessages := config.GetEnvAsInt("SQS_MAX_MESSAGES", 10)
+	if maxMessages < 1 || maxMessages > 10 {
+		maxMessages = 10
+	}
+
+	log.Printf("SQS client initialized (region=%s, queue=%s)", cfg.Region, queueURL)
+
+	return &SQS{
+		Client:      client,
+		queueURL:    queueURL,
+		waitSeconds: int32(waitSeconds),
+		maxMessages: int32(maxMessages),
+	}, nil
+}
+
+// Receive 롱 폴링으로 메시지 수신 (대기 시간 안에 메시지가 없으면 빈 목록)
+func (q *SQS) Receive(ctx context.Context) ([]QueueMessage, error) {
+	result, err := q.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(q.queueURL),
+		MaxNumberOfMessages: q.maxMessages,
+		WaitTimeSeconds:     q.waitSeconds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive SQS messages: %w", err)
+	}
+
+	messages := make([]QueueMessage, 0, len(result.Messages))
+	for _, m := range result.Messages {
+		messages = append(messages, QueueMessage{
+			MessageId:     aws.ToString(m.MessageId),
+			ReceiptHandle: aws.ToString(m.ReceiptHandle),
+			Body:          aws.ToString(m.Body),
+		})
+	}
+	return messages, nil
+}
+
+// Delete 처리가 끝난 메시지 삭제
+func (q *SQS) Delete(ctx context.Context, receiptHandle string) error {
+	if _, err := q.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(q.queueURL),
+		ReceiptHandle: aws.String(receiptHandle),
+	}); err != nil {
+		return fmt.Errorf("failed to delete SQS message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// fakeSQSServer SQS JSON 프로토콜을 흉내 내는 로컬 대기열 (ElasticMQ 등 호환 서버 대용)
+type fakeSQSServer struct {
+	mu       sync.Mutex
+	queueURL string
+	bodies   map[string]string // ReceiptHandle별 메시지 본문
+	deleted  []string
+}
+
+func (f *fakeSQSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		QueueUrl            string
+		MaxNumberOfMessages int32
+		WaitTimeSeconds     int32
+		ReceiptHandle       string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil || in.QueueUrl != f.queueURL {
+		http.Error(w, `{"__type":"com.amazonaws.sqs#QueueDoesNotExist"}`, http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+
+	switch r.Header.Get("X-Amz-Target") {
+	case "AmazonSQS.ReceiveMessage":
+		type message struct {
+			MessageId     string
+			ReceiptHandle string
+			Body          string
+			MD5OfBody     string
+		}
+		var out struct{ Messages []message }
+		for handle, body := range f.bodies {
+			if int32(len(out.Messages)) == in.MaxNumberOfMessages {
+				break
+			}
+			sum := md5.Sum([]byte(body))
+			out.Messages = append(out.Messages, message{
+				MessageId:     "id-" + handle,
+				ReceiptHandle: handle,
+				Body:          body,
+				MD5OfBody:     hex.EncodeToString(sum[:]),
+			})
+		}
+		json.NewEncoder(w).Encode(out)
+	case "AmazonSQS.DeleteMessage":
+		delete(f.bodies, in.ReceiptHandle)
+		f.deleted = append(f.deleted, in.ReceiptHandle)
+		w.Write([]byte("{}"))
+	default:
+		http.Error(w, `{"__type":"com.amazonaws.sqs#InvalidAction"}`, http.StatusBadRequest)
+	}
+}
+
+// TestSQSReceiveAndDelete SQS_ENDPOINT로 지정한 로컬 대기열에서 메시지 수신 및 삭제
+func TestSQSReceiveAndDelete(t *testing.T) {
+	fake := &fakeSQSServer{
+		queueURL: "http://localhost/000000000000/ses-events",
+		bodies:   map[string]string{"h-1": `{"notificationType":"Delivery"}`},
+	}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	t.Setenv("SQS_ENDPOINT", srv.URL)
+	t.Setenv("SQS_WAIT_SECONDS", "0")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	ctx := context.Background()
+	q, err := NewSQSClient(ctx, fake.queueURL)
+	if err != nil {
+		t.Fatalf("NewSQSClient() 에러 = %v", err)
+	}
+
+	messages, err := q.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Receive() 에러 = %v", err)
+	}
+	if len(messages) != 1 || messages[0].ReceiptHandle != "h-1" || messages[0].Body != `{"notificationType":"Delivery"}` {
+		t.Fatalf("Receive() = %+v", messages)
+	}
+
+	if err := q.Delete(ctx, messages[0].ReceiptHandle); err != nil {
+		t.Fatalf("Delete() 에러 = %v", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "h-1" {
+		t.Errorf("삭제된 메시지 = %v, 예상 = [h-1]", fake.deleted)
+	}
+
+	if messages, err = q.Receive(ctx); err != nil || len(messages) != 0 {
+		t.Errorf("삭제 후 Receive() = %+v, %v, 예상 = 빈 목록", messages, err)
+	}
+}
+
+// TestNewSQSClientRequiresQueueURL 대기열 URL 없이 생성하면 에러
+func TestNewSQSClientRequiresQueueURL(t *testing.T) {
+	if _, err := NewSQSClient(context.Background(), ""); err == nil {
+		t.Error("대기열 URL이 없을 때 에러가 예상됨")
+	}
+}
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// suppressionPageSize ListSuppressedDestinations 한 번에 조회할 최대 항목 수 (SES 최대값)
+const suppressionPageSize = 1000
+
+// SuppressedDestination SES 계정 수신 거부 목록 항목
+type SuppressedDestination struct {
+	Email          string    // 수신 주소
+	Reason         string    // BOUNCE, COMPLAINT
+	LastUpdateTime time.Time // SES 목록에 추가되거나 갱신된 시각
+}
+
+// ListSuppressedDestinations SES 계정 수신 거부 목록 한 페이지 조회
+// since가 0이 아니면 그 이후에 추가/갱신된 항목만 조회하고, 다음 페이지 토큰이 비어 있으면 마지막 페이지
+func (s *SES) ListSuppressedDestinations(ctx context.Context, since time.Time, nextToken string) ([]SuppressedDestination, string, error) {
+	input := &sesv2.ListSuppressedDestinationsInput{
+		PageSize: aws.Int32(suppressionPageSize),
+	}
+	if !since.IsZero() {
+		input.StartDate = aws.Time(since)
+	}
+	if nextToken != "" {
+		input.NextToken = aws.String(nextToken)
+	}
+
+	result, err := s.Client.ListSuppressedDestinations(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list SES suppressed destinations: %w", err)
+	}
+
+	list := make([]SuppressedDestination, 0, len(result.SuppressedDestinationSummaries))
+	for _, d := range result.SuppressedDestinationSummaries {
+		if d.EmailAddress == nil {
+			continue
+		}
+		item := SuppressedDestination{Email: *d.EmailAddress, Reason: string(d.Reason)}
+		if d.LastUpdateTime != nil {
+			item.LastUpdateTime = *d.LastUpdateTime
+		}
+		list = append(list, item)
+	}
+	return list, aws.ToString(result.NextToken), nil
+}
+
+// PutSuppressedDestination SES 계정 수신 거부 목록에 주소 추가 (reason은 BOUNCE 또는 COMPLAINT)
+func (s *SES) PutSuppressedDestination(ctx context.Context, email, reason string) error {
+	_, err := s.Client.PutSuppressedDestination(ctx, &sesv2.PutSuppressedDestinationInput{
+		EmailAddress: aws.String(email),
+		Reason:       types.SuppressionListReason(reason),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put SES suppressed destination %s: %w", email, err)
+	}
+	return nil
+}
+
+// DeleteSuppressedDestination SES 계정 수신 거부 목록에서 주소 삭제 (목록에 없으면 성공으로 처리)
+func (s *SES) DeleteSuppressedDestination(ctx context.Context, email string) error {
+	_, err := s.Client.DeleteSuppressedDestination(ctx, &sesv2.DeleteSuppressedDestinationInput{
+		EmailAddress: aws.String(email),
+	})
+	var notFound *types.NotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		return fmt.Errorf("failed to delete SES suppressed destination %s: %w", email, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestListSuppressedDestinations 증분 조회 조건과 페이지 토큰 전달 테스트
+func TestListSuppressedDestinations(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ses := newFakeSES(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/email/suppression/addresses" {
+			t.Errorf("예상치 못한 요청: %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("PageSize") != "1000" || q.Get("NextToken") != "page-2" {
+			t.Errorf("PageSize/NextToken = %s/%s", q.Get("PageSize"), q.Get("NextToken"))
+		}
+		if got, _ := time.Parse(time.RFC3339, q.Get("StartDate")); !got.Equal(since) {
+			t.Errorf("StartDate = %s, 예상 = %s", q.Get("StartDate"), since)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"SuppressedDestinationSummaries":[
+			{"EmailAddress":"a@example.com","Reason":"BOUNCE","LastUpdateTime":1714521600},
+			{"EmailAddress":"b@example.com","Reason":"COMPLAINT","LastUpdateTime":1714525200}
+		],"NextToken":"page-3"}`)
+	})
+
+	list, next, err := ses.ListSuppressedDestinations(context.Background(), since, "page-2")
+	if err != nil {
+		t.Fatalf("ListSuppressedDestinations() 에러 = %v", err)
+	}
+	if next != "page-3" {
+		t.Errorf("다음 페이지 토큰 = %q, 예상 = page-3", next)
+	}
+	if len(list) != 2 || list[0].Email != "a@example.com" || list[1].Reason != "COMPLAINT" {
+		t.Fatalf("목록 = %+v", list)
+	}
+	if !list[0].LastUpdateTime.Equal(since) {
+		t.Errorf("LastUpdateTime = %s, 예상 = %s", list[0].LastUpdateTime, since)
+	}
+}
+
+// TestPutAndDeleteSuppressedDestination SES 목록 추가/삭제 요청과 삭제 대상이 없는 경우 테스트
+func TestPutAndDeleteSuppressedDestination(t *testing.T) {
+	var calls []string
+	ses := newFakeSES(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/missing@example.com") {
+			w.Header().Set("X-Amzn-Errortype", "NotFoundException")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Email address not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	ctx := context.Background()
+	if err := ses.PutSuppressedDestination(ctx, "a@example.com", "BOUNCE"); err != nil {
+		t.Fatalf("PutSuppressedDestination() 에러 = %v", err)
+	}
+	if err := ses.DeleteSuppressedDestination(ctx, "a@example.com"); err != nil {
+		t.Fatalf("DeleteSuppressedDestination() 에러 = %v", err)
+	}
+	if err := ses.DeleteSuppressedDestination(ctx, "missing@example.com"); err != nil {
+		t.Errorf("목록에 없는 주소 삭제 에러 = %v, 예상 = nil", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("호출 수 = %d, 예상 = 3 (%v)", len(calls), calls)
+	}
+	if !strings.HasPrefix(calls[0], "PUT /v2/email/suppression/addresses ") ||
+		!strings.Contains(calls[0], `"EmailAddress":"a@example.com"`) || !strings.Contains(calls[0], `"Reason":"BOUNCE"`) {
+		t.Errorf("추가 요청 = %s", calls[0])
+	}
+	if !strings.HasPrefix(calls[1], "DELETE /v2/email/suppression/addresses/a@example.com") {
+		t.Errorf("삭제 요청 = %s", calls[1])
+	}
+}
@@ -0,0 +1,107 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewMessageID 발신자 도메인 기반 Message-ID 생성 (꺾쇠 괄호 제외)
+func NewMessageID(reqID uint, sender string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(sender, "@"); at >= 0 && at < len(sender)-1 {
+		domain = strings.TrimSuffix(sender[at+1:], ">")
+	}
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	return fmt.Sprintf("%d.%s.%s@%s", reqID, strconv.FormatInt(time.Now().UnixNano(), 36), hex.EncodeToString(buf), domain)
+}
+
+// Render 메시지를 RFC 5322 형식의 원문으로 변환
+func Render(from string, msg *Message, messageID string, date time.Time) ([]byte, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", from)
+	writeHeader(&buf, "To", strings.Join(msg.To, ", "))
+	writeHeader(&buf, "Subject", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	writeHeader(&buf, "Date", date.Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", "<"+messageID+">")
+	writeHeader(&buf, "MIME-Version", "1.0")
+	writeHeader(&buf, "X-Request-ID", strconv.FormatUint(uint64(msg.RequestID), 10))
+	for _, h := range msg.UnsubscribeHeaders() {
+		writeHeader(&buf, h[0], h[1])
+	}
+
+	// 텍스트 본문이 없으면 HTML 단일 파트
+	if msg.Text == "" {
+		writeHeader(&buf, "Content-Type", `text/html; charset="UTF-8"`)
+		writeHeader(&buf, "Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, msg.HTML); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+		return buf.Bytes(), nil
+	}
+
+	// 텍스트와 HTML 본문을 함께 보내는 multipart/alternative (선호도가 낮은 텍스트 먼저)
+	mw := multipart.NewWriter(&buf)
+	writeHeader(&buf, "Content-Type", `multipart/alternative; boundary="`+mw.Boundary()+`"`)
+	buf.WriteString("\r\n")
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{`text/plain; charset="UTF-8"`, msg.Text},
+		{`text/html; charset="UTF-8"`, msg.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create body part: %w", err)
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable 본문을 quoted-printable로 인코딩하여 기록
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("failed to encode body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("failed to encode body: %w", err)
+	}
+	return nil
+}
+
+// writeHeader 헤더 한 줄 기록 (헤더 인젝션 방지를 위해 개행 문자 제거)
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,155 @@
+package mailer
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRender RFC 5322 메시지 생성 테스트
+func TestRender(t *testing.T) {
+	msg := &Message{
+		RequestID: 42,
+		To:        []string{"user@example.com"},
+		Subject:   "안녕하세요",
+		HTML:      "<p>본문</p>",
+
+		UnsubscribeURL: "https://mail.example.com/v1/unsubscribe?requestId=42&sig=abc",
+	}
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	raw, err := Render("sender@example.com", msg, "abc@example.com", date)
+	if err != nil {
+		t.Fatalf("Render() 에러 = %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("생성된 메시지 파싱 실패: %v", err)
+	}
+
+	tests := []struct {
+		header string // 헤더 이름
+		want   string // 예상 값
+	}{
+		{"From", "sender@example.com"},
+		{"To", "user@example.com"},
+		{"Message-ID", "<abc@example.com>"},
+		{"X-Request-ID", "42"},
+		{"MIME-Version", "1.0"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+		{"List-Unsubscribe", "<https://mail.example.com/v1/unsubscribe?requestId=42&sig=abc>"},
+		{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"},
+	}
+	for _, tt := range tests {
+		if got := parsed.Header.Get(tt.header); got != tt.want {
+			t.Errorf("%s = %q, 예상 = %q", tt.header, got, tt.want)
+		}
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "안녕하세요" {
+		t.Errorf("Subject 디코딩 = %q (에러: %v), 예상 = %q", subject, err, "안녕하세요")
+	}
+
+	body, _ := io.ReadAll(parsed.Body)
+	if !strings.Contains(string(body), "<p>") {
+		t.Errorf("본문에 HTML이 없음: %s", body)
+	}
+}
+
+// TestRenderHeaderInjection 헤더 값의 개행 문자 제거 검증
+func TestRenderHeaderInjection(t *testing.T) {
+	msg := &Message{
+		RequestID: 1,
+		To:        []string{"user@example.com\r\nBcc: evil@example.com"},
+		Subject:   "제목",
+		HTML:      "본문",
+	}
+
+	raw, err := Render("sender@example.com", msg, "id@example.com", time.Now())
+	if err != nil {
+		t.Fatalf("Render() 에러 = %v", err)
+	}
+	if strings.Contains(string(raw), "\r\nBcc:") {
+		t.Error("헤더 인젝션이 차단되지 않음")
+	}
+}
+
+// TestNewMessageID 발신자 도메인 기반 Message-ID 생성 테스트
+func TestNewMessageID(t *testing.T) {
+	tests := []struct {
+		name       string // 테스트 케이스 이름
+		sender     string // 발신자 주소
+		wantSuffix string // 예상 도메인 접미사
+	}{
+		{"일반 주소", "noreply@example.com", "@example.com"},
+		{"이름이 포함된 주소", "Service <noreply@example.org>", "@example.org"},
+		{"도메인이 없는 주소", "noreply", "@localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := NewMessageID(7, tt.sender)
+			if !strings.HasSuffix(id, tt.wantSuffix) || !strings.HasPrefix(id, "7.") {
+				t.Errorf("NewMessageID() = %q, 예상 접두사 = 7., 접미사 = %s", id, tt.wantSuffix)
+			}
+		})
+	}
+
+	if NewMessageID(1, "a@example.com") == NewMessageID(1, "a@example.com") {
+		t.Error("Message-ID가 고유하지 않음")
+	}
+}
+
+// TestRenderMultipart 텍스트 본문이 있으면 multipart/alternative로 생성되는지 검증
+func TestRenderMultipart(t *testing.T) {
+	msg := &Message{
+		RequestID: 1,
+		To:        []string{"user@example.com"},
+		Subject:   "제목",
+		HTML:      "<p>HTML 본문</p>",
+		Text:      "텍스트 본문",
+	}
+
+	raw, err := Render("sender@example.com", msg, "id@example.com", time.Now())
+	if err != nil {
+		t.Fatalf("Render() 에러 = %v", err)
+	}
+	parsed, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("생성된 메시지 파싱 실패: %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q (에러: %v), 예상 = multipart/alternative", mediaType, err)
+	}
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	wantParts := []struct {
+		contentType string // 예상 파트 Content-Type
+		body        string // 예상 파트 본문
+	}{
+		{"text/plain", "텍스트 본문"},
+		{"text/html", "<p>HTML 본문</p>"},
+	}
+	for _, want := range wantParts {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("파트 읽기 실패: %v", err)
+		}
+		if ct, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type")); ct != want.contentType {
+			t.Errorf("파트 Content-Type = %q, 예상 = %q", ct, want.contentType)
+		}
+		// multipart.Reader는 quoted-printable 파트를 자동으로 디코딩
+		body, _ := io.ReadAll(part)
+		if string(body) != want.body {
+			t.Errorf("파트 본문 = %q, 예상 = %q", body, want.body)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Message 발송할 이메일 메시지
+type Message struct {
+	RequestID uint     // 발송 요청 ID (X-Request-ID 헤더로 전달)
+	To        []string // 수신자 목록
+	Subject   string   // 제목
+	HTML      string   // HTML 본문
+	Text      string   // 텍스트 본문 (선택, 있으면 multipart/alternative로 발송)
+	// UnsubscribeURL 원클릭 수신 거부 URL (선택, 있으면 List-Unsubscribe, List-Unsubscribe-Post 헤더 추가)
+	UnsubscribeURL string
+}
+
+// ListUnsubscribePost RFC 8058 원클릭 수신 거부 헤더 값
+const ListUnsubscribePost = "List-Unsubscribe=One-Click"
+
+// UnsubscribeHeaders 수신 거부 URL이 있으면 List-Unsubscribe 헤더 이름과 값 목록 반환
+func (m *Message) UnsubscribeHeaders() [][2]string {
+	if m.UnsubscribeURL == "" {
+		return nil
+	}
+	return [][2]string{
+		{"List-Unsubscribe", "<" + m.UnsubscribeURL + ">"},
+		{"List-Unsubscribe-Post", ListUnsubscribePost},
+	}
+}
+
+// Validate 메시지 필드 검증
+func (m *Message) Validate() error {
+	if m == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+	if m.Subject == "" {
+		return fmt.Errorf("subject cannot be empty")
+	}
+	if m.HTML == "" {
+		return fmt.Errorf("body cannot be empty")
+	}
+	if len(m.To) == 0 {
+		return fmt.Errorf("receivers list cannot be empty")
+	}
+	return nil
+}
+
+// Mailer 이메일 발송 제공자 인터페이스
+type Mailer interface {
+	// Send 메시지를 발송하고 제공자 메시지 ID 반환 (실패 시 *Error로 분류된 에러 반환)
+	Send(ctx context.Context, msg *Message) (string, error)
+}
+
+// Quota 계정 발송 한도
+type Quota struct {
+	MaxSendRate     float64 // 초당 최대 발송 수
+	Max24HourSend   float64 // 24시간 최대 발송 수 (0 이하이면 무제한)
+	SentLast24Hours float64 // 최근 24시간 발송 수
+}
+
+// QuotaProvider 계정 발송 한도를 조회할 수 있는 제공자 (선택 구현)
+type QuotaProvider interface {
+	// Quota 현재 계정 발송 한도 조회
+	Quota(ctx context.Context) (*Quota, error)
+}
+
+// ErrorKind 발송 에러 분류
+type ErrorKind int
+
+const (
+	ErrorKindPermanent ErrorKind = iota // 재시도해도 실패하는 에러
+	ErrorKindTransient                  // 일시적인 에러 (재시도 가능)
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindTransient:
+		return "transient"
+	default:
+		return "permanent"
+	}
+}
+
+// Error 분류 정보가 포함된 발송 에러
+type Error struct {
+	Kind      ErrorKind
+	Throttled bool // 발송 속도 제한 초과 여부
+	Err       error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Permanent 영구 에러로 분류
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: ErrorKindPermanent, Err: err}
+}
+
+// Transient 일시적 에러로 분류
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: ErrorKindTransient, Err: err}
+}
+
+// Throttled 발송 속도 제한 초과 에러로 분류 (일시적 에러)
+func Throttled(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: ErrorKindTransient, Throttled: true, Err: err}
+}
+
+// KindOf 에러 분류 조회 (분류되지 않은 에러는 영구 에러로 간주)
+func KindOf(err error) ErrorKind {
+	var mErr *Error
+	if errors.As(err, &mErr) {
+		return mErr.Kind
+	}
+	return ErrorKindPermanent
+}
+
+// IsTransient 일시적 에러 여부 확인
+func IsTransient(err error) bool {
+	return err != nil && KindOf(err) == ErrorKindTransient
+}
+
+// IsThrottled 발송 속도 제한 초과 에러 여부 확인
+func IsThrottled(err error) bool {
+	var mErr *Error
+	return errors.As(err, &mErr) && mErr.Throttled
+}
@@ -0,0 +1,100 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestMessageValidate Message 필드 검증 테스트
+func TestMessageValidate(t *testing.T) {
+	tests := []struct {
+		name    string   // 테스트 케이스 이름
+		msg     *Message // 검증할 메시지
+		wantErr string   // 예상 에러 메시지 (빈 문자열이면 에러 없음)
+	}{
+		{
+			name:    "정상적인 메시지",
+			msg:     &Message{RequestID: 1, To: []string{"a@example.com"}, Subject: "제목", HTML: "<p>본문</p>"},
+			wantErr: "",
+		},
+		{
+			name:    "nil 메시지",
+			msg:     nil,
+			wantErr: "message cannot be nil",
+		},
+		{
+			name:    "제목이 빈 문자열",
+			msg:     &Message{To: []string{"a@example.com"}, HTML: "본문"},
+			wantErr: "subject cannot be empty",
+		},
+		{
+			name:    "본문이 빈 문자열",
+			msg:     &Message{To: []string{"a@example.com"}, Subject: "제목"},
+			wantErr: "body cannot be empty",
+		},
+		{
+			name:    "수신자가 없음",
+			msg:     &Message{Subject: "제목", HTML: "본문"},
+			wantErr: "receivers list cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() 에러가 예상되지 않았지만 발생함 = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() 에러 = %v, 예상 = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestErrorKind 에러 분류 헬퍼 테스트
+func TestErrorKind(t *testing.T) {
+	base := errors.New("boom")
+
+	tests := []struct {
+		name          string    // 테스트 케이스 이름
+		err           error     // 검사할 에러
+		wantKind      ErrorKind // 예상 분류
+		wantTransient bool      // 일시적 에러 여부
+	}{
+		{"일시적 에러", Transient(base), ErrorKindTransient, true},
+		{"영구 에러", Permanent(base), ErrorKindPermanent, false},
+		{"분류되지 않은 에러는 영구 에러", base, ErrorKindPermanent, false},
+		{"래핑된 일시적 에러", fmt.Errorf("wrapped: %w", Transient(base)), ErrorKindTransient, true},
+		{"속도 제한 에러는 일시적 에러", Throttled(base), ErrorKindTransient, true},
+		{"nil 에러", nil, ErrorKindPermanent, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.wantKind {
+				t.Errorf("KindOf() = %v, 예상 = %v", got, tt.wantKind)
+			}
+			if got := IsTransient(tt.err); got != tt.wantTransient {
+				t.Errorf("IsTransient() = %v, 예상 = %v", got, tt.wantTransient)
+			}
+		})
+	}
+
+	if !errors.Is(Transient(base), base) {
+		t.Error("Transient()가 원본 에러를 래핑하지 않음")
+	}
+	if !IsThrottled(fmt.Errorf("wrapped: %w", Throttled(base))) {
+		t.Error("IsThrottled()가 래핑된 속도 제한 에러를 인식하지 못함")
+	}
+	if IsThrottled(Transient(base)) {
+		t.Error("일반 일시적 에러가 속도 제한 에러로 인식됨")
+	}
+	if Transient(nil) != nil || Permanent(nil) != nil || Throttled(nil) != nil {
+		t.Error("nil 에러는 nil로 반환되어야 함")
+	}
+}
@@ -0,0 +1,223 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var wordDecoder = new(mime.WordDecoder)
+
+// ErrMessageNotFound 캡처된 메시지가 없는 경우
+var ErrMessageNotFound = errors.New("captured message not found")
+
+// Captured 캡처된 메시지 요약 정보
+type Captured struct {
+	ID        string    `json:"id"`
+	RequestID uint      `json:"requestId"`
+	To        []string  `json:"to"`
+	Subject   string    `json:"subject"`
+	Size      int       `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Mailbox 캡처된 메시지 조회 인터페이스
+type Mailbox interface {
+	// List 캡처된 메시지 목록 (최신순)
+	List() ([]Captured, error)
+	// Get 메시지 원문(.eml) 조회
+	Get(id string) (*Captured, []byte, error)
+}
+
+// MemorySink 메시지를 메모리에 보관하는 발송 제공자 (실제 발송 없음)
+type MemorySink struct {
+	sender string
+	limit  int
+
+	mu       sync.RWMutex
+	messages []memoryEntry
+}
+
+type memoryEntry struct {
+	info Captured
+	raw  []byte
+}
+
+// NewMemorySink 메모리 메일함 생성 (limit 초과 시 오래된 메시지부터 삭제)
+func NewMemorySink(sender string, limit int) *MemorySink {
+	if limit <= 0 {
+		limit = 1000
+	}
+	return &MemorySink{sender: sender, limit: limit}
+}
+
+// Send mailer.Mailer 구현 (메시지를 렌더링하여 메모리에 저장)
+func (s *MemorySink) Send(_ context.Context, msg *Message) (string, error) {
+	info, raw, err := capture(s.sender, msg)
+	if err != nil {
+		return "", Permanent(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, memoryEntry{info: *info, raw: raw})
+	if over := len(s.messages) - s.limit; over > 0 {
+		s.messages = append([]memoryEntry(nil), s.messages[over:]...)
+	}
+	return info.ID, nil
+}
+
+// List Mailbox 구현
+func (s *MemorySink) List() ([]Captured, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]Captured, 0, len(s.messages))
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		list = append(list, s.messages[i].info)
+	}
+	return list, nil
+}
+
+// Get Mailbox 구현
+func (s *MemorySink) Get(id string) (*Captured, []byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, e := range s.messages {
+		if e.info.ID == id {
+			info := e.info
+			return &info, append([]byte(nil), e.raw...), nil
+		}
+	}
+	return nil, nil, ErrMessageNotFound
+}
+
+// FileSink 메시지를 디렉터리에 .eml 파일로 저장하는 발송 제공자 (실제 발송 없음)
+type FileSink struct {
+	sender string
+	dir    string
+}
+
+// NewFileSink 파일 메일함 생성 (디렉터리가 없으면 생성)
+func NewFileSink(sender, dir string) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create sink directory: %w", err)
+	}
+	return &FileSink{sender: sender, dir: dir}, nil
+}
+
+// Send mailer.Mailer 구현 (메시지를 .eml 파일로 저장)
+func (s *FileSink) Send(_ context.Context, msg *Message) (string, error) {
+	info, raw, err := capture(s.sender, msg)
+	if err != nil {
+		return "", Permanent(err)
+	}
+
+	path := filepath.Join(s.dir, info.ID+".eml")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return "", Transient(fmt.Errorf("failed to write message file: %w", err))
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", Transient(fmt.Errorf("failed to write message file: %w", err))
+	}
+	return info.ID, nil
+}
+
+// List Mailbox 구현
+func (s *FileSink) List() ([]Captured, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sink directory: %w", err)
+	}
+
+	list := make([]Captured, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".eml") {
+			continue
+		}
+		info, _, err := s.Get(strings.TrimSuffix(entry.Name(), ".eml"))
+		if err != nil {
+			continue
+		}
+		list = append(list, *info)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// Get Mailbox 구현
+func (s *FileSink) Get(id string) (*Captured, []byte, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return nil, nil, ErrMessageNotFound
+	}
+	raw, err := os.ReadFile(filepath.Join(s.dir, id+".eml"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, ErrMessageNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to read message file: %w", err)
+	}
+	info, err := parseCaptured(id, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, raw, nil
+}
+
+// capture 메시지를 렌더링하고 합성 메시지 ID 부여
+func capture(sender string, msg *Message) (*Captured, []byte, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	now := time.Now().UTC()
+	id := NewMessageID(msg.RequestID, sender)
+	raw, err := Render(sender, msg, id, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Captured{
+		ID:        id,
+		RequestID: msg.RequestID,
+		To:        msg.To,
+		Subject:   msg.Subject,
+		Size:      len(raw),
+		CreatedAt: now,
+	}, raw, nil
+}
+
+// parseCaptured 저장된 .eml 원문에서 요약 정보 추출
+func parseCaptured(id string, raw []byte) (*Captured, error) {
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse message file: %w", err)
+	}
+	info := &Captured{ID: id, Size: len(raw)}
+	if reqID, err := strconv.ParseUint(parsed.Header.Get("X-Request-ID"), 10, 64); err == nil {
+		info.RequestID = uint(reqID)
+	}
+	if addrs, err := parsed.Header.AddressList("To"); err == nil {
+		for _, addr := range addrs {
+			info.To = append(info.To, addr.Address)
+		}
+	}
+	if subject, err := wordDecoder.DecodeHeader(parsed.Header.Get("Subject")); err == nil {
+		info.Subject = subject
+	}
+	if date, err := parsed.Header.Date(); err == nil {
+		info.CreatedAt = date.UTC()
+	}
+	return info, nil
+}
@@ -0,0 +1,102 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sinkMessage(reqID uint) *Message {
+	return &Message{
+		RequestID: reqID,
+		To:        []string{"user@example.com"},
+		Subject:   fmt.Sprintf("제목 %d", reqID),
+		HTML:      `<p>본문</p><img src="http://localhost/v1/events/open?requestId=1" />`,
+	}
+}
+
+// TestFileSink .eml 파일 저장 및 조회 테스트
+func TestFileSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewFileSink("sender@example.com", filepath.Join(dir, "mailbox"))
+	if err != nil {
+		t.Fatalf("NewFileSink() 에러 = %v", err)
+	}
+
+	id, err := sink.Send(context.Background(), sinkMessage(3))
+	if err != nil {
+		t.Fatalf("Send() 에러 = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mailbox", id+".eml")); err != nil {
+		t.Fatalf(".eml 파일이 생성되지 않음: %v", err)
+	}
+
+	list, err := sink.List()
+	if err != nil {
+		t.Fatalf("List() 에러 = %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("List() 건수 = %d, 예상 = 1", len(list))
+	}
+	if list[0].ID != id || list[0].RequestID != 3 || list[0].Subject != "제목 3" {
+		t.Errorf("List()[0] = %+v, 예상 ID = %s, RequestID = 3, Subject = 제목 3", list[0], id)
+	}
+	if len(list[0].To) != 1 || list[0].To[0] != "user@example.com" {
+		t.Errorf("To = %v, 예상 = [user@example.com]", list[0].To)
+	}
+
+	_, raw, err := sink.Get(id)
+	if err != nil {
+		t.Fatalf("Get() 에러 = %v", err)
+	}
+	if !strings.Contains(string(raw), "X-Request-ID: 3") {
+		t.Errorf("원문에 X-Request-ID 헤더가 없음: %s", raw)
+	}
+
+	for _, badID := range []string{"", "../secret", "a/b", ".hidden", "missing"} {
+		if _, _, err := sink.Get(badID); !errors.Is(err, ErrMessageNotFound) {
+			t.Errorf("Get(%q) 에러 = %v, 예상 = ErrMessageNotFound", badID, err)
+		}
+	}
+}
+
+// TestMemorySink 메모리 메일함 저장 및 최대 보관 수 테스트
+func TestMemorySink(t *testing.T) {
+	sink := NewMemorySink("sender@example.com", 2)
+
+	var ids []string
+	for i := 1; i <= 3; i++ {
+		id, err := sink.Send(context.Background(), sinkMessage(uint(i)))
+		if err != nil {
+			t.Fatalf("Send() 에러 = %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	list, _ := sink.List()
+	if len(list) != 2 {
+		t.Fatalf("List() 건수 = %d, 예상 = 2", len(list))
+	}
+	if list[0].ID != ids[2] || list[1].ID != ids[1] {
+		t.Errorf("List() 순서가 최신순이 아님: %v", list)
+	}
+	if _, _, err := sink.Get(ids[0]); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("오래된 메시지가 삭제되지 않음: %v", err)
+	}
+	if _, raw, err := sink.Get(ids[2]); err != nil || !strings.Contains(string(raw), "v1/events/open") {
+		t.Errorf("Get() 원문에 트래킹 픽셀이 없음 (에러: %v)", err)
+	}
+}
+
+// TestSinkValidation 잘못된 메시지는 영구 에러로 분류
+func TestSinkValidation(t *testing.T) {
+	sink := NewMemorySink("sender@example.com", 0)
+	_, err := sink.Send(context.Background(), &Message{RequestID: 1})
+	if err == nil || IsTransient(err) {
+		t.Errorf("Send() 에러 = %v, 예상 = 영구 에러", err)
+	}
+}
@@ -0,0 +1,225 @@
+package sesevent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SES 이벤트 유형 (알림의 notificationType, 이벤트 게시의 eventType 공통, 공백 제거)
+const (
+	TypeBounce           = "Bounce"
+	TypeComplaint        = "Complaint"
+	TypeDelivery         = "Delivery"
+	TypeDeliveryDelay    = "DeliveryDelay"
+	TypeReject           = "Reject"
+	TypeRenderingFailure = "RenderingFailure"
+	TypeSend             = "Send"
+	TypeOpen             = "Open"
+	TypeClick            = "Click"
+	TypeSubscription     = "Subscription"
+)
+
+// 반송 유형
+const (
+	BounceUndetermined = "Undetermined"
+	BouncePermanent    = "Permanent"
+	BounceTransient    = "Transient"
+)
+
+// ErrInvalidEvent SES 이벤트 형식이 아닌 경우
+var ErrInvalidEvent = errors.New("invalid SES event")
+
+// Header 메일 헤더
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Mail 이벤트 대상 메일 정보
+type Mail struct {
+	Timestamp        time.Time           `json:"timestamp"`
+	MessageId        string              `json:"messageId"`
+	Source           string              `json:"source"`
+	SourceArn        string              `json:"sourceArn"`
+	SendingAccountId string              `json:"sendingAccountId"`
+	Destination      []string            `json:"destination"`
+	HeadersTruncated bool                `json:"headersTruncated"`
+	Headers          []Header            `json:"headers"`
+	Tags             map[string][]string `json:"tags"`
+}
+
+// Header 이름으로 헤더 값 조회 (대소문자 구분 없음)
+func (m *Mail) Header(name string) string {
+	for _, h := range m.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+// Recipient 수신자별 결과 (반송, 수신 거부 신고, 지연)
+type Recipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	Action         string `json:"action,omitempty"`
+	Status         string `json:"status,omitempty"`
+	DiagnosticCode string `json:"diagnosticCode,omitempty"`
+}
+
+// Bounce 반송
+type Bounce struct {
+	BounceType        string      `json:"bounceType"`    // Undetermined, Permanent, Transient
+	BounceSubType     string      `json:"bounceSubType"` // General, NoEmail, Suppressed, MailboxFull 등
+	BouncedRecipients []Recipient `json:"bouncedRecipients"`
+	Timestamp         time.Time   `json:"timestamp"`
+	FeedbackId        string      `json:"feedbackId"`
+	ReportingMTA      string      `json:"reportingMTA"`
+}
+
+// Complaint 수신 거부 신고
+type Complaint struct {
+	ComplainedRecipients  []Recipient `json:"complainedRecipients"`
+	Timestamp             time.Time   `json:"timestamp"`
+	FeedbackId            string      `json:"feedbackId"`
+	ComplaintSubType      string      `json:"complaintSubType"`
+	ComplaintFeedbackType string      `json:"complaintFeedbackType"` // abuse, fraud, not-spam 등
+	UserAgent             string      `json:"userAgent"`
+}
+
+// Delivery 전달 완료
+type Delivery struct {
+	Timestamp            time.Time `json:"timestamp"`
+	ProcessingTimeMillis int64     `json:"processingTimeMillis"`
+	Recipients           []string  `json:"recipients"`
+	SmtpResponse         string    `json:"smtpResponse"`
+	ReportingMTA         string    `json:"reportingMTA"`
+	RemoteMtaIp          string    `json:"remoteMtaIp"`
+}
+
+// DeliveryDelay 전달 지연
+type DeliveryDelay struct {
+	DelayType         string      `json:"delayType"`
+	DelayedRecipients []Recipient `json:"delayedRecipients"`
+	ExpirationTime    time.Time   `json:"expirationTime"`
+	ReportingMTA      string      `json:"reportingMTA"`
+	Timestamp         time.Time   `json:"timestamp"`
+}
+
+// Reject 발송 거부 (바이러스 포함 등)
+type Reject struct {
+	Reason string `json:"reason"`
+}
+
+// RenderingFailure 템플릿 렌더링 실패
+type RenderingFailure struct {
+	TemplateName string `json:"templateName"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// Open SES 열람 추적
+type Open struct {
+	IpAddress string    `json:"ipAddress"`
+	Timestamp time.Time `json:"timestamp"`
+	UserAgent string    `json:"userAgent"`
+}
+
+// Click SES 클릭 추적
+type Click struct {
+	IpAddress string              `json:"ipAddress"`
+	Timestamp time.Time           `json:"timestamp"`
+	UserAgent string              `json:"userAgent"`
+	Link      string              `json:"link"`
+	LinkTags  map[string][]string `json:"linkTags"`
+}
+
+// Subscription 구독 관리(연락처 목록) 변경
+type Subscription struct {
+	ContactList         string          `json:"contactList"`
+	Timestamp           time.Time       `json:"timestamp"`
+	Source              string          `json:"source"`
+	NewTopicPreferences json.RawMessage `json:"newTopicPreferences"`
+	OldTopicPreferences json.RawMessage `json:"oldTopicPreferences"`
+}
+
+// Event SES 알림 또는 이벤트 게시 메시지
+type Event struct {
+	Type             string            `json:"-"`
+	NotificationType string            `json:"notificationType"`
+	EventType        string            `json:"eventType"`
+	Mail             Mail              `json:"mail"`
+	Bounce           *Bounce           `json:"bounce"`
+	Complaint        *Complaint        `json:"complaint"`
+	Delivery         *Delivery         `json:"delivery"`
+	DeliveryDelay    *DeliveryDelay    `json:"deliveryDelay"`
+	Reject           *Reject           `json:"reject"`
+	Failure          *RenderingFailure `json:"failure"`
+	Send             *struct{}         `json:"send"`
+	Open             *Open             `json:"open"`
+	Click            *Click            `json:"click"`
+	Subscription     *Subscription     `json:"subscription"`
+}
+
+// Parse SES 알림(notificationType) 또는 이벤트 게시(eventType) 메시지 파싱
+func Parse(data []byte) (*Event, error) {
+	ev := &Event{}
+	if err := json.Unmarshal(data, ev); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	kind := ev.EventType
+	if kind == "" {
+		kind = ev.NotificationType
+	}
+	ev.Type = strings.ReplaceAll(kind, " ", "")
+	if ev.Type == "" {
+		return nil, fmt.Errorf("%w: missing notificationType or eventType", ErrInvalidEvent)
+	}
+	return ev, nil
+}
+
+// Timestamp 이벤트 발생 시각 (유형별 시각이 없으면 메일 발송 시각)
+func (e *Event) Timestamp() time.Time {
+	var ts time.Time
+	switch {
+	case e.Bounce != nil:
+		ts = e.Bounce.Timestamp
+	case e.Complaint != nil:
+		ts = e.Complaint.Timestamp
+	case e.Delivery != nil:
+		ts = e.Delivery.Timestamp
+	case e.DeliveryDelay != nil:
+		ts = e.DeliveryDelay.Timestamp
+	case e.Open != nil:
+		ts = e.Open.Timestamp
+	case e.Click != nil:
+		ts = e.Click.Timestamp
+	case e.Subscription != nil:
+		ts = e.Subscription.Timestamp
+	}
+	if ts.IsZero() {
+		return e.Mail.Timestamp
+	}
+	return ts
+}
+
+// Recipients 이벤트의 수신자별 결과 (반송, 수신 거부 신고, 지연, 전달 수신자)
+func (e *Event) Recipients() []Recipient {
+	switch {
+	case e.Bounce != nil:
+		return e.Bounce.BouncedRecipients
+	case e.Complaint != nil:
+		return e.Complaint.ComplainedRecipients
+	case e.DeliveryDelay != nil:
+		return e.DeliveryDelay.DelayedRecipients
+	case e.Delivery != nil:
+		recipients := make([]Recipient, 0, len(e.Delivery.Recipients))
+		for _, addr := range e.Delivery.Recipients {
+			recipients = append(recipients, Recipient{EmailAddress: addr})
+		}
+		return recipients
+	}
+	return nil
+}
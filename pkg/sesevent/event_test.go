@@ -0,0 +1,98 @@
+package sesevent
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestParse SES 알림/이벤트 게시 메시지 파싱 테스트
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string    // 테스트 케이스 이름
+		data           string    // SES 메시지 본문
+		wantType       string    // 예상 이벤트 유형
+		wantRecipients int       // 예상 수신자 수
+		wantTimestamp  time.Time // 예상 이벤트 발생 시각
+		wantErr        bool      // 에러 발생 예상 여부
+	}{
+		{
+			name: "알림 형식 반송",
+			data: `{"notificationType":"Bounce","mail":{"timestamp":"2024-01-01T00:00:00Z","messageId":"m-1"},
+				"bounce":{"bounceType":"Permanent","bounceSubType":"NoEmail","timestamp":"2024-01-01T00:00:05Z",
+				"bouncedRecipients":[{"emailAddress":"a@example.com","action":"failed","status":"5.1.1","diagnosticCode":"smtp; 550"}]}}`,
+			wantType:       TypeBounce,
+			wantRecipients: 1,
+			wantTimestamp:  time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC),
+		},
+		{
+			name: "이벤트 게시 형식 수신 거부 신고",
+			data: `{"eventType":"Complaint","mail":{"timestamp":"2024-01-01T00:00:00Z","messageId":"m-2"},
+				"complaint":{"complaintFeedbackType":"abuse","timestamp":"2024-01-01T00:01:00Z",
+				"complainedRecipients":[{"emailAddress":"a@example.com"},{"emailAddress":"b@example.com"}]}}`,
+			wantType:       TypeComplaint,
+			wantRecipients: 2,
+			wantTimestamp:  time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+		},
+		{
+			name: "전달 완료",
+			data: `{"notificationType":"Delivery","mail":{"timestamp":"2024-01-01T00:00:00Z","messageId":"m-3"},
+				"delivery":{"timestamp":"2024-01-01T00:00:02Z","processingTimeMillis":2000,"recipients":["a@example.com"]}}`,
+			wantType:       TypeDelivery,
+			wantRecipients: 1,
+			wantTimestamp:  time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+		},
+		{
+			name: "공백이 포함된 렌더링 실패 유형",
+			data: `{"eventType":"Rendering Failure","mail":{"timestamp":"2024-01-01T00:00:00Z","messageId":"m-4"},
+				"failure":{"templateName":"welcome","errorMessage":"missing var"}}`,
+			wantType:      TypeRenderingFailure,
+			wantTimestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "유형 없음",
+			data:    `{"mail":{"messageId":"m-5"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "JSON 형식 오류",
+			data:    `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := Parse([]byte(tt.data))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEvent) {
+					t.Errorf("Parse() 에러 = %v, 예상 = %v", err, ErrInvalidEvent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() 에러 = %v", err)
+			}
+			if ev.Type != tt.wantType {
+				t.Errorf("Type = %q, 예상 = %q", ev.Type, tt.wantType)
+			}
+			if got := len(ev.Recipients()); got != tt.wantRecipients {
+				t.Errorf("Recipients() 수 = %d, 예상 = %d", got, tt.wantRecipients)
+			}
+			if got := ev.Timestamp(); !got.Equal(tt.wantTimestamp) {
+				t.Errorf("Timestamp() = %v, 예상 = %v", got, tt.wantTimestamp)
+			}
+		})
+	}
+}
+
+// TestMailHeader 메일 헤더 조회는 대소문자를 구분하지 않음
+func TestMailHeader(t *testing.T) {
+	m := Mail{Headers: []Header{{Name: "X-Request-ID", Value: "42"}}}
+	if got := m.Header("x-request-id"); got != "42" {
+		t.Errorf("Header() = %q, 예상 = %q", got, "42")
+	}
+	if got := m.Header("X-Missing"); got != "" {
+		t.Errorf("Header() = %q, 예상 = 빈 문자열", got)
+	}
+}
@@ -0,0 +1,393 @@
+package smtp
+
+import (
+	"aws-ses-sender-go/config"
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/mail"
+	netsmtp "net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TLSMode SMTP 연결 보안 방식
+type TLSMode string
+
+const (
+	TLSModeNone     TLSMode = "none"     // 평문 연결
+	TLSModeStartTLS TLSMode = "starttls" // 평문 연결 후 STARTTLS로 전환
+	TLSModeImplicit TLSMode = "implicit" // 연결 시점부터 TLS (SMTPS)
+)
+
+// Options SMTP 클라이언트 설정
+type Options struct {
+	Addr        string        // 서버 주소 (host:port)
+	Username    string        // 인증 사용자명 (비어 있으면 인증 생략)
+	Password    string        // 인증 비밀번호
+	AuthMethod  string        // 인증 방식 (plain, login)
+	TLSMode     TLSMode       // 연결 보안 방식
+	TLSConfig   *tls.Config   // TLS 설정 (nil이면 서버 호스트명으로 검증)
+	Sender      string        // 발신자 주소
+	PoolSize    int           // 최대 연결 수
+	IdleTimeout time.Duration // 유휴 연결 재사용 허용 시간
+	DialTimeout time.Duration // 연결 타임아웃
+}
+
+// Client 연결 풀을 사용하는 SMTP 발송 클라이언트
+type Client struct {
+	opts     Options
+	host     string
+	envelope string
+	auth     netsmtp.Auth
+	idle     chan *conn
+	slots    chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// conn 풀에서 관리되는 SMTP 연결
+type conn struct {
+	client   *netsmtp.Client
+	netConn  net.Conn
+	lastUsed time.Time
+}
+
+// NewSMTPClient 환경 변수 기반 SMTP 클라이언트 생성
+func NewSMTPClient() (*Client, error) {
+	host := config.GetEnv("SMTP_HOST")
+	if host == "" {
+		return nil, fmt.Errorf("SMTP_HOST environment variable is required")
+	}
+	senderEmail := config.GetEnv("EMAIL_SENDER")
+	if senderEmail == "" {
+		return nil, fmt.Errorf("EMAIL_SENDER environment variable is required")
+	}
+
+	opts := Options{
+		Addr:        net.JoinHostPort(host, config.GetEnv("SMTP_PORT", "587")),
+		Username:    config.GetEnv("SMTP_USERNAME"),
+		Password:    config.GetEnv("SMTP_PASSWORD"),
+		AuthMethod:  config.GetEnv("SMTP_AUTH", "plain"),
+		TLSMode:     TLSMode(strings.ToLower(config.GetEnv("SMTP_TLS", string(TLSModeStartTLS)))),
+		Sender:      senderEmail,
+		PoolSize:    config.GetEnvAsInt("SMTP_POOL_SIZE", 4),
+		IdleTimeout: time.Duration(config.GetEnvAsInt("SMTP_IDLE_TIMEOUT_SEC", 30)) * time.Second,
+	}
+
+	c, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("SMTP client initialized (addr=%s, tls=%s, pool=%d, sender=%s)",
+		opts.Addr, opts.TLSMode, opts.PoolSize, senderEmail)
+	return c, nil
+}
+
+// New 옵션 기반 SMTP 클라이언트 생성
+func New(opts Options) (*Client, error) {
+	host, _, err := net.SplitHostPort(opts.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", opts.Addr, err)
+	}
+	if opts.Sender == "" {
+		return nil, fmt.Errorf("sender cannot be empty")
+	}
+	sender, err := mail.ParseAddress(opts.Sender)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", opts.Sender, err)
+	}
+	switch opts.TLSMode {
+	case "":
+		opts.TLSMode = TLSModeStartTLS
+	case TLSModeNone, TLSModeStartTLS, TLSModeImplicit:
+	default:
+		return nil, fmt.Errorf("unsupported SMTP TLS mode: %s", opts.TLSMode)
+	}
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = 1
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = 30 * time.Second
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 10 * time.Second
+	}
+	if opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{ServerName: host}
+	}
+
+	var auth netsmtp.Auth
+	if opts.Username != "" {
+		switch strings.ToLower(opts.AuthMethod) {
+		case "", "plain":
+			auth = netsmtp.PlainAuth("", opts.Username, opts.Password, host)
+		case "login":
+			auth = &loginAuth{username: opts.Username, password: opts.Password}
+		default:
+			return nil, fmt.Errorf("unsupported SMTP auth method: %s", opts.AuthMethod)
+		}
+	}
+
+	return &Client{
+		opts:     opts,
+		host:     host,
+		envelope: sender.Address,
+		auth:     auth,
+		idle:     make(chan *conn, opts.PoolSize),
+		slots:    make(chan struct{}, opts.PoolSize),
+	}, nil
+}
+
+// Send mailer.Mailer 구현 (발송 후 생성된 Message-ID 반환)
+func (c *Client) Send(ctx context.Context, msg *mailer.Message) (string, error) {
+	if err := msg.Validate(); err != nil {
+		return "", mailer.Permanent(err)
+	}
+
+	msgID := mailer.NewMessageID(msg.RequestID, c.opts.Sender)
+	raw, err := mailer.Render(c.opts.Sender, msg, msgID, time.Now())
+	if err != nil {
+		return "", mailer.Permanent(err)
+	}
+
+	cn, err := c.acquire(ctx)
+	if err != nil {
+		return "", classifyError(fmt.Errorf("failed to connect to SMTP server: %w", err))
+	}
+
+	if err := cn.send(ctx, c.envelope, msg.To, raw); err != nil {
+		c.release(cn, isConnReusable(err))
+		return "", classifyError(fmt.Errorf("failed to send email via SMTP: %w", err))
+	}
+	c.release(cn, true)
+
+	return msgID, nil
+}
+
+// Close 유휴 연결 종료
+func (c *Client) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.close()
+			<-c.slots
+		default:
+			return nil
+		}
+	}
+}
+
+// acquire 풀에서 연결 획득 (유휴 연결이 없으면 새 연결 생성)
+func (c *Client) acquire(ctx context.Context) (*conn, error) {
+	for {
+		// 유휴 연결 우선 사용
+		select {
+		case cn := <-c.idle:
+			if c.discardIfStale(cn) {
+				continue
+			}
+			return cn, nil
+		default:
+		}
+
+		select {
+		case cn := <-c.idle:
+			if c.discardIfStale(cn) {
+				continue
+			}
+			return cn, nil
+		case c.slots <- struct{}{}:
+			cn, err := c.dial(ctx)
+			if err != nil {
+				<-c.slots
+				return nil, err
+			}
+			return cn, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// discardIfStale 유휴 시간이 초과된 연결 종료
+func (c *Client) discardIfStale(cn *conn) bool {
+	if time.Since(cn.lastUsed) <= c.opts.IdleTimeout {
+		return false
+	}
+	cn.close()
+	<-c.slots
+	return true
+}
+
+// release 연결 반환 (재사용 불가능하면 종료)
+func (c *Client) release(cn *conn, reusable bool) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+
+	if reusable && !closed {
+		if err := cn.client.Reset(); err == nil {
+			cn.lastUsed = time.Now()
+			cn.netConn.SetDeadline(time.Time{})
+			c.idle <- cn
+			return
+		}
+	}
+	cn.close()
+	<-c.slots
+}
+
+// dial 새 SMTP 연결 생성 (TLS 및 인증 포함)
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	dialer := &net.Dialer{Timeout: c.opts.DialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		netConn.SetDeadline(deadline)
+	}
+
+	if c.opts.TLSMode == TLSModeImplicit {
+		tlsConn := tls.Client(netConn, c.opts.TLSConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			netConn.Close()
+			return nil, fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		netConn = tlsConn
+	}
+
+	client, err := netsmtp.NewClient(netConn, c.host)
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	if c.opts.TLSMode == TLSModeStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(c.opts.TLSConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	if c.auth != nil {
+		if err := client.Auth(c.auth); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	return &conn{client: client, netConn: netConn, lastUsed: time.Now()}, nil
+}
+
+// send 하나의 메일 트랜잭션 수행
+func (cn *conn) send(ctx context.Context, from string, to []string, raw []byte) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		cn.netConn.SetDeadline(deadline)
+	}
+
+	if err := cn.client.Mail(from); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := cn.client.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := cn.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(raw); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// close 연결 종료
+func (cn *conn) close() {
+	if err := cn.client.Quit(); err != nil {
+		cn.client.Close()
+	}
+}
+
+// isConnReusable 서버 응답 에러(연결은 정상)인 경우에만 연결 재사용
+func isConnReusable(err error) bool {
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr)
+}
+
+// classifyError SMTP 에러를 일시적/영구 에러로 분류 (4xx 일시적, 5xx 영구)
+func classifyError(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		if protoErr.Code >= 400 && protoErr.Code < 500 {
+			return mailer.Transient(err)
+		}
+		return mailer.Permanent(err)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return mailer.Transient(err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return mailer.Transient(err)
+	}
+
+	return mailer.Permanent(err)
+}
+
+// loginAuth AUTH LOGIN 인증 구현 (net/smtp는 PLAIN/CRAM-MD5만 제공)
+type loginAuth struct {
+	username string
+	password string
+}
+
+func (a *loginAuth) Start(server *netsmtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, fmt.Errorf("unencrypted connection")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	prompt := strings.ToLower(strings.TrimSpace(string(fromServer)))
+	switch {
+	case strings.HasPrefix(prompt, "username"):
+		return []byte(a.username), nil
+	case strings.HasPrefix(prompt, "password"):
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected server challenge: %s", fromServer)
+	}
+}
+
+// isLocalhost 로컬 서버 여부 확인
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
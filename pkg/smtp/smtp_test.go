@@ -0,0 +1,418 @@
+package smtp
+
+import (
+	"aws-ses-sender-go/pkg/mailer"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServer 테스트용 인프로세스 SMTP 서버
+type fakeServer struct {
+	ln        net.Listener
+	tlsConfig *tls.Config // STARTTLS 지원 시 사용할 TLS 설정
+	username  string
+	password  string
+	rejectTo  map[string]int // 수신자별 RCPT 거부 응답 코드
+
+	mu       sync.Mutex
+	conns    int
+	messages []string
+}
+
+// newFakeServer 테스트용 SMTP 서버 시작 (implicit이면 TLS 리스너 사용)
+func newFakeServer(t *testing.T, tlsConfig *tls.Config, implicit bool) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("리스너 생성 실패: %v", err)
+	}
+	if implicit {
+		ln = tls.NewListener(ln, tlsConfig)
+		tlsConfig = nil
+	}
+	s := &fakeServer{
+		ln:        ln,
+		tlsConfig: tlsConfig,
+		username:  "user",
+		password:  "secret",
+		rejectTo:  map[string]int{},
+	}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeServer) serve() {
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+func (s *fakeServer) handle(c net.Conn) {
+	defer c.Close()
+	tp := textproto.NewConn(c)
+	_, isTLS := c.(*tls.Conn)
+	tp.PrintfLine("220 localhost ESMTP fake")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			lines := []string{"localhost", "AUTH PLAIN LOGIN"}
+			if s.tlsConfig != nil && !isTLS {
+				lines = append(lines, "STARTTLS")
+			}
+			for i, l := range lines {
+				sep := "-"
+				if i == len(lines)-1 {
+					sep = " "
+				}
+				tp.PrintfLine("250%s%s", sep, l)
+			}
+		case "STARTTLS":
+			tp.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(c, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			c = tlsConn
+			tp = textproto.NewConn(c)
+			isTLS = true
+		case "AUTH":
+			s.handleAuth(tp, arg)
+		case "MAIL", "RSET", "NOOP":
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			addr := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<> ")
+			if code, ok := s.rejectTo[addr]; ok {
+				tp.PrintfLine("%d recipient rejected", code)
+				continue
+			}
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, string(data))
+			s.mu.Unlock()
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+func (s *fakeServer) handleAuth(tp *textproto.Conn, arg string) {
+	mech, initial, _ := strings.Cut(arg, " ")
+	ok := false
+	switch strings.ToUpper(mech) {
+	case "PLAIN":
+		decoded, _ := base64.StdEncoding.DecodeString(initial)
+		parts := strings.Split(string(decoded), "\x00")
+		ok = len(parts) == 3 && parts[1] == s.username && parts[2] == s.password
+	case "LOGIN":
+		tp.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte("Username:")))
+		userLine, _ := tp.ReadLine()
+		tp.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte("Password:")))
+		passLine, _ := tp.ReadLine()
+		user, _ := base64.StdEncoding.DecodeString(userLine)
+		pass, _ := base64.StdEncoding.DecodeString(passLine)
+		ok = string(user) == s.username && string(pass) == s.password
+	}
+	if ok {
+		tp.PrintfLine("235 authenticated")
+	} else {
+		tp.PrintfLine("535 authentication failed")
+	}
+}
+
+func (s *fakeServer) stats() (int, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns, append([]string(nil), s.messages...)
+}
+
+// newTestTLSConfig 127.0.0.1용 자체 서명 인증서로 서버/클라이언트 TLS 설정 생성
+func newTestTLSConfig(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("키 생성 실패: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("인증서 생성 실패: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	serverCfg := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	clientCfg := &tls.Config{RootCAs: pool, ServerName: "127.0.0.1"}
+	return serverCfg, clientCfg
+}
+
+func testMessage(reqID uint, to string) *mailer.Message {
+	return &mailer.Message{
+		RequestID: reqID,
+		To:        []string{to},
+		Subject:   "테스트 제목",
+		HTML:      "<p>Hello</p>",
+	}
+}
+
+// TestSendWithConnectionReuse 연결 재사용 및 Message-ID 반환 테스트
+func TestSendWithConnectionReuse(t *testing.T) {
+	srv := newFakeServer(t, nil, false)
+	c, err := New(Options{
+		Addr:     srv.ln.Addr().String(),
+		Username: "user",
+		Password: "secret",
+		TLSMode:  TLSModeNone,
+		Sender:   "Sender <sender@example.com>",
+		PoolSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("New() 에러 = %v", err)
+	}
+	defer c.Close()
+
+	var ids []string
+	for i := 1; i <= 3; i++ {
+		id, err := c.Send(context.Background(), testMessage(uint(i), "to@example.com"))
+		if err != nil {
+			t.Fatalf("Send() 에러 = %v", err)
+		}
+		if !strings.HasSuffix(id, "@example.com") {
+			t.Errorf("Message-ID = %q, 발신자 도메인으로 끝나야 함", id)
+		}
+		ids = append(ids, id)
+	}
+
+	conns, messages := srv.stats()
+	if conns != 1 {
+		t.Errorf("연결 수 = %d, 예상 = 1 (연결 재사용)", conns)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("수신 메시지 수 = %d, 예상 = 3", len(messages))
+	}
+	if !strings.Contains(messages[0], "X-Request-ID: 1") {
+		t.Errorf("X-Request-ID 헤더가 없음: %s", messages[0])
+	}
+	if !strings.Contains(messages[0], "Message-ID: <"+ids[0]+">") {
+		t.Errorf("Message-ID 헤더가 반환값과 다름: %s", messages[0])
+	}
+}
+
+// TestSendTLSModes STARTTLS/Implicit TLS 및 AUTH PLAIN/LOGIN 조합 테스트
+func TestSendTLSModes(t *testing.T) {
+	serverCfg, clientCfg := newTestTLSConfig(t)
+
+	tests := []struct {
+		name     string  // 테스트 케이스 이름
+		mode     TLSMode // 연결 보안 방식
+		auth     string  // 인증 방식
+		implicit bool    // 서버 TLS 리스너 사용 여부
+	}{
+		{"STARTTLS + AUTH PLAIN", TLSModeStartTLS, "plain", false},
+		{"STARTTLS + AUTH LOGIN", TLSModeStartTLS, "login", false},
+		{"Implicit TLS + AUTH LOGIN", TLSModeImplicit, "login", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeServer(t, serverCfg, tt.implicit)
+			c, err := New(Options{
+				Addr:       srv.ln.Addr().String(),
+				Username:   "user",
+				Password:   "secret",
+				AuthMethod: tt.auth,
+				TLSMode:    tt.mode,
+				TLSConfig:  clientCfg,
+				Sender:     "sender@example.com",
+			})
+			if err != nil {
+				t.Fatalf("New() 에러 = %v", err)
+			}
+			defer c.Close()
+
+			if _, err := c.Send(context.Background(), testMessage(1, "to@example.com")); err != nil {
+				t.Fatalf("Send() 에러 = %v", err)
+			}
+			if _, messages := srv.stats(); len(messages) != 1 {
+				t.Errorf("수신 메시지 수 = %d, 예상 = 1", len(messages))
+			}
+		})
+	}
+}
+
+// TestSendErrorClassification SMTP 응답 코드별 에러 분류 테스트
+func TestSendErrorClassification(t *testing.T) {
+	srv := newFakeServer(t, nil, false)
+	srv.rejectTo["busy@example.com"] = 451
+	srv.rejectTo["unknown@example.com"] = 550
+
+	c, err := New(Options{
+		Addr:     srv.ln.Addr().String(),
+		TLSMode:  TLSModeNone,
+		Sender:   "sender@example.com",
+		PoolSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("New() 에러 = %v", err)
+	}
+	defer c.Close()
+
+	tests := []struct {
+		name          string // 테스트 케이스 이름
+		to            string // 수신자
+		wantErr       bool   // 에러 발생 예상 여부
+		wantTransient bool   // 일시적 에러 여부
+	}{
+		{"4xx 응답은 일시적 에러", "busy@example.com", true, true},
+		{"5xx 응답은 영구 에러", "unknown@example.com", true, false},
+		{"거부 후에도 같은 연결로 발송 성공", "ok@example.com", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Send(context.Background(), testMessage(1, tt.to))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() 에러 = %v, 에러 예상 = %v", err, tt.wantErr)
+			}
+			if err != nil && mailer.IsTransient(err) != tt.wantTransient {
+				t.Errorf("IsTransient() = %v, 예상 = %v", mailer.IsTransient(err), tt.wantTransient)
+			}
+		})
+	}
+
+	if conns, _ := srv.stats(); conns != 1 {
+		t.Errorf("연결 수 = %d, 예상 = 1 (서버 거부 응답 후 연결 재사용)", conns)
+	}
+}
+
+// TestSendAuthFailure 인증 실패는 영구 에러로 분류
+func TestSendAuthFailure(t *testing.T) {
+	srv := newFakeServer(t, nil, false)
+	c, err := New(Options{
+		Addr:     srv.ln.Addr().String(),
+		Username: "user",
+		Password: "wrong",
+		TLSMode:  TLSModeNone,
+		Sender:   "sender@example.com",
+	})
+	if err != nil {
+		t.Fatalf("New() 에러 = %v", err)
+	}
+	defer c.Close()
+
+	_, err = c.Send(context.Background(), testMessage(1, "to@example.com"))
+	if err == nil {
+		t.Fatal("인증 실패 에러를 예상했지만 nil이 반환됨")
+	}
+	if mailer.IsTransient(err) {
+		t.Errorf("인증 실패가 일시적 에러로 분류됨: %v", err)
+	}
+}
+
+// TestSendPoolLimit 동시 발송 시 연결 수가 풀 크기를 넘지 않는지 검증
+func TestSendPoolLimit(t *testing.T) {
+	srv := newFakeServer(t, nil, false)
+	c, err := New(Options{
+		Addr:     srv.ln.Addr().String(),
+		TLSMode:  TLSModeNone,
+		Sender:   "sender@example.com",
+		PoolSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("New() 에러 = %v", err)
+	}
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := c.Send(context.Background(), testMessage(uint(i), fmt.Sprintf("to%d@example.com", i))); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Send() 에러 = %v", err)
+	}
+
+	conns, messages := srv.stats()
+	if conns > 2 {
+		t.Errorf("연결 수 = %d, 풀 크기(2)를 초과함", conns)
+	}
+	if len(messages) != 10 {
+		t.Errorf("수신 메시지 수 = %d, 예상 = 10", len(messages))
+	}
+}
+
+// TestNewValidation 옵션 검증 테스트
+func TestNewValidation(t *testing.T) {
+	tests := []struct {
+		name string  // 테스트 케이스 이름
+		opts Options // 클라이언트 옵션
+	}{
+		{"잘못된 주소", Options{Addr: "no-port", Sender: "a@example.com"}},
+		{"발신자 없음", Options{Addr: "127.0.0.1:25"}},
+		{"잘못된 TLS 모드", Options{Addr: "127.0.0.1:25", Sender: "a@example.com", TLSMode: "ssl3"}},
+		{"잘못된 인증 방식", Options{Addr: "127.0.0.1:25", Sender: "a@example.com", Username: "u", AuthMethod: "cram"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.opts); err == nil {
+				t.Error("New() 에러를 예상했지만 nil이 반환됨")
+			}
+		})
+	}
+}
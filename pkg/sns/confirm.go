@@ -0,0 +1,68 @@
+package sns
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrInvalidSubscribeURL 구독 확인 URL이 SNS 도메인이 아닌 경우
+var ErrInvalidSubscribeURL = errors.New("invalid SNS subscribe URL")
+
+// ValidateSubscribeURL 구독 확인 URL이 SNS 도메인의 HTTPS URL인지 검증
+func ValidateSubscribeURL(subscribeURL string) error {
+	u, err := url.Parse(subscribeURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSubscribeURL, err)
+	}
+	if u.Scheme != "https" || u.User != nil || u.Port() != "" || !certHostPattern.MatchString(u.Hostname()) {
+		return fmt.Errorf("%w: %s", ErrInvalidSubscribeURL, subscribeURL)
+	}
+	return nil
+}
+
+// Confirmer SubscribeURL 호출로 구독 확인
+type Confirmer struct {
+	Client *http.Client
+}
+
+// Confirm 구독 확인 URL을 호출하고 응답의 SubscriptionArn 반환
+func (c *Confirmer) Confirm(ctx context.Context, subscribeURL string) (string, error) {
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscribeURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create confirm request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to confirm subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read confirm response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to confirm subscription: status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		SubscriptionArn string `xml:"ConfirmSubscriptionResult>SubscriptionArn"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse confirm response: %w", err)
+	}
+	if result.SubscriptionArn == "" {
+		return "", errors.New("confirm response has no SubscriptionArn")
+	}
+	return result.SubscriptionArn, nil
+}
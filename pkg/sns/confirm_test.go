@@ -0,0 +1,79 @@
+package sns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestConfirm 구독 확인 URL 호출 및 SubscriptionArn 파싱 테스트
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string // 테스트 케이스 이름
+		status  int    // 응답 상태 코드
+		body    string // 응답 본문
+		wantArn string // 예상 SubscriptionArn
+		wantErr bool   // 에러 발생 예상 여부
+	}{
+		{
+			name:   "구독 확인 성공",
+			status: http.StatusOK,
+			body: `<ConfirmSubscriptionResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/">
+  <ConfirmSubscriptionResult>
+    <SubscriptionArn>arn:aws:sns:us-east-1:123456789012:ses-events:2bcfbf39</SubscriptionArn>
+  </ConfirmSubscriptionResult>
+</ConfirmSubscriptionResponse>`,
+			wantArn: "arn:aws:sns:us-east-1:123456789012:ses-events:2bcfbf39",
+		},
+		{
+			name:    "만료된 토큰",
+			status:  http.StatusForbidden,
+			body:    `<ErrorResponse><Error><Code>AuthorizationError</Code></Error></ErrorResponse>`,
+			wantErr: true,
+		},
+		{
+			name:    "SubscriptionArn 없음",
+			status:  http.StatusOK,
+			body:    `<ConfirmSubscriptionResponse/>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			arn, err := (&Confirmer{Client: srv.Client()}).Confirm(context.Background(), srv.URL+"/?Action=ConfirmSubscription&Token=t")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Confirm() 에러 = %v, 예상 에러 여부 = %v", err, tt.wantErr)
+			}
+			if arn != tt.wantArn {
+				t.Errorf("Confirm() = %q, 예상 = %q", arn, tt.wantArn)
+			}
+		})
+	}
+}
+
+// TestValidateSubscribeURL 구독 확인 URL 검증 테스트
+func TestValidateSubscribeURL(t *testing.T) {
+	tests := []struct {
+		url     string // 구독 확인 URL
+		wantErr bool   // 에러 발생 예상 여부
+	}{
+		{"https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&TopicArn=arn&Token=t", false},
+		{"http://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription", true},
+		{"https://sns.us-east-1.amazonaws.com.evil.example/?Action=ConfirmSubscription", true},
+		{"https://169.254.169.254/latest/meta-data/", true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateSubscribeURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSubscribeURL(%q) 에러 = %v, 예상 에러 여부 = %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package sns
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SNS 메시지 유형
+const (
+	TypeNotification             = "Notification"
+	TypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	TypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+var (
+	// ErrInvalidSignature 서명이 없거나 일치하지 않는 경우
+	ErrInvalidSignature = errors.New("invalid SNS message signature")
+	// ErrInvalidCertURL 서명 인증서 URL이 SNS 도메인이 아닌 경우
+	ErrInvalidCertURL = errors.New("invalid SNS signing certificate URL")
+	// ErrTopicNotAllowed 허용되지 않은 TopicArn인 경우
+	ErrTopicNotAllowed = errors.New("SNS topic is not allowed")
+)
+
+// Message SNS HTTP(S) 엔드포인트로 전달되는 메시지
+type Message struct {
+	Type             string `json:"Type"`
+	MessageId        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	UnsubscribeURL   string `json:"UnsubscribeURL,omitempty"`
+}
+
+// StringToSign 메시지 유형별 서명 대상 문자열
+func (m *Message) StringToSign() (string, error) {
+	var fields [][2]string
+	switch m.Type {
+	case TypeNotification:
+		fields = [][2]string{{"Message", m.Message}, {"MessageId", m.MessageId}}
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields, [][2]string{{"Timestamp", m.Timestamp}, {"TopicArn", m.TopicArn}, {"Type", m.Type}}...)
+	case TypeSubscriptionConfirmation, TypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", m.Message}, {"MessageId", m.MessageId}, {"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp}, {"Token", m.Token}, {"TopicArn", m.TopicArn}, {"Type", m.Type},
+		}
+	default:
+		return "", fmt.Errorf("unsupported SNS message type: %s", m.Type)
+	}
+
+	var sb strings.Builder
+	for _, f := range fields {
+		sb.WriteString(f[0])
+		sb.WriteByte('\n')
+		sb.WriteString(f[1])
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// CertFetcher 서명 인증서 조회 (테스트에서 로컬 인증서로 교체 가능)
+type CertFetcher interface {
+	Fetch(ctx context.Context, certURL string) (*x509.Certificate, error)
+}
+
+// HTTPCertFetcher HTTPS로 서명 인증서(PEM) 조회
+type HTTPCertFetcher struct {
+	Client *http.Client
+}
+
+// Fetch 인증서 URL에서 PEM 인증서를 내려받아 파싱
+func (f *HTTPCertFetcher) Fetch(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	client := f.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch signing certificate: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing certificate: %w", err)
+	}
+	return ParseCertificate(body)
+}
+
+// ParseCertificate PEM 인코딩된 인증서 파싱
+func ParseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("signing certificate is not a PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// certHostPattern SNS 서명 인증서 호스트 (sns.<region>.amazonaws.com)
+var certHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// ValidateCertURL 서명 인증서 URL이 SNS 도메인의 HTTPS PEM 파일인지 검증
+func ValidateCertURL(certURL string) error {
+	u, err := url.Parse(certURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCertURL, err)
+	}
+	if u.Scheme != "https" || u.User != nil || u.Port() != "" || !certHostPattern.MatchString(u.Hostname()) {
+		return fmt.Errorf("%w: %s", ErrInvalidCertURL, certURL)
+	}
+	if !strings.HasSuffix(u.Path, ".pem") {
+		return fmt.Errorf("%w: %s", ErrInvalidCertURL, certURL)
+	}
+	return nil
+}
+
+// Verifier SNS 메시지 서명 및 TopicArn 검증
+type Verifier struct {
+	fetcher CertFetcher
+	topics  map[string]struct{} // 허용 TopicArn (비어 있으면 알림은 모두 거부, 구독 메시지만 허용)
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate // 인증서 URL별 캐시
+}
+
+// NewVerifier 인증서 조회기와 허용 TopicArn 목록으로 Verifier 생성
+func NewVerifier(fetcher CertFetcher, topicArns []string) *Verifier {
+	v := &Verifier{
+		fetcher: fetcher,
+		topics:  make(map[string]struct{}),
+		certs:   make(map[string]*x509.Certificate),
+	}
+	for _, arn := range topicArns {
+		if arn = strings.TrimSpace(arn); arn != "" {
+			v.topics[arn] = struct{}{}
+		}
+	}
+	return v
+}
+
+// TopicAllowed 메시지 유형별 TopicArn 허용 여부
+// 알림은 허용 목록에 명시된 토픽만 허용 (허용 목록이 비어 있으면 거부)
+// 구독 메시지는 허용 목록이 비어 있으면 확인 대기 상태로 기록할 수 있도록 모든 토픽 허용
+func (v *Verifier) TopicAllowed(msgType, topicArn string) bool {
+	if msgType != TypeNotification && len(v.topics) == 0 {
+		return true
+	}
+	_, ok := v.topics[topicArn]
+	return ok
+}
+
+// Allowlisted TopicArn이 허용 목록에 명시되어 있는지 여부 (허용 목록이 비어 있으면 false)
+func (v *Verifier) Allowlisted(topicArn string) bool {
+	_, ok := v.topics[topicArn]
+	return ok
+}
+
+// Verify TopicArn 허용 여부와 메시지 서명 검증 (SignatureVersion 1: SHA1, 2: SHA256)
+func (v *Verifier) Verify(ctx context.Context, msg *Message) error {
+	if !v.TopicAllowed(msg.Type, msg.TopicArn) {
+		return fmt.Errorf("%w: %s", ErrTopicNotAllowed, msg.TopicArn)
+	}
+
+	var hash crypto.Hash
+	switch msg.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("%w: unsupported signature version %q", ErrInvalidSignature, msg.SignatureVersion)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil || len(signature) == 0 {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+	stringToSign, err := msg.StringToSign()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	cert, err := v.certificate(ctx, msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: signing certificate is not an RSA key", ErrInvalidSignature)
+	}
+
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(stringToSign))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(stringToSign))
+		digest = sum[:]
+	}
+	if err := rsa.VerifyPKCS1v15(pub, hash, digest, signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return nil
+}
+
+// certificate 인증서 URL 검증 후 캐시된 인증서 반환 (없거나 만료되면 조회)
+func (v *Verifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if err := ValidateCertURL(certURL); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	v.mu.Lock()
+	cert, ok := v.certs[certURL]
+	v.mu.Unlock()
+	if ok && now.Before(cert.NotAfter) {
+		return cert, nil
+	}
+
+	cert, err := v.fetcher.Fetch(ctx, certURL)
+	if err != nil {
+		return nil, err
+	}
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, fmt.Errorf("%w: signing certificate is not valid at %s", ErrInvalidSignature, now.Format(time.RFC3339))
+	}
+
+	v.mu.Lock()
+	v.certs[certURL] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
@@ -0,0 +1,274 @@
+package sns
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testCertURL = "https://sns.ap-northeast-2.amazonaws.com/SimpleNotificationService-test.pem"
+
+// fakeCertFetcher 로컬에서 생성한 인증서를 반환하는 조회기
+type fakeCertFetcher struct {
+	mu    sync.Mutex
+	cert  *x509.Certificate
+	calls int
+}
+
+func (f *fakeCertFetcher) Fetch(_ context.Context, _ string) (*x509.Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.cert, nil
+}
+
+// newTestSigner 테스트용 RSA 키와 자체 서명 인증서 생성
+func newTestSigner(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("RSA 키 생성 실패: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("인증서 생성 실패: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("인증서 파싱 실패: %v", err)
+	}
+	return key, cert
+}
+
+// signMessage 메시지 서명 (SignatureVersion에 따라 SHA1 또는 SHA256)
+func signMessage(t *testing.T, key *rsa.PrivateKey, msg *Message) {
+	t.Helper()
+	s, err := msg.StringToSign()
+	if err != nil {
+		t.Fatalf("서명 대상 생성 실패: %v", err)
+	}
+	var sig []byte
+	if msg.SignatureVersion == "1" {
+		sum := sha1.Sum([]byte(s))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, sum[:])
+	} else {
+		sum := sha256.Sum256([]byte(s))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	}
+	if err != nil {
+		t.Fatalf("서명 실패: %v", err)
+	}
+	msg.Signature = base64.StdEncoding.EncodeToString(sig)
+}
+
+// TestVerify SNS 메시지 서명 검증 테스트
+func TestVerify(t *testing.T) {
+	key, cert := newTestSigner(t)
+	const topic = "arn:aws:sns:ap-northeast-2:123456789012:ses-events"
+
+	newMessage := func(version string) *Message {
+		return &Message{
+			Type:             TypeNotification,
+			MessageId:        "msg-1",
+			TopicArn:         topic,
+			Message:          `{"notificationType":"Delivery"}`,
+			Timestamp:        "2024-01-01T00:00:00.000Z",
+			SignatureVersion: version,
+			SigningCertURL:   testCertURL,
+		}
+	}
+
+	tests := []struct {
+		name    string          // 테스트 케이스 이름
+		build   func() *Message // 검증할 메시지 생성
+		wantErr error           // 예상 에러 (nil이면 성공)
+	}{
+		{
+			name: "SignatureVersion 1",
+			build: func() *Message {
+				m := newMessage("1")
+				signMessage(t, key, m)
+				return m
+			},
+		},
+		{
+			name: "SignatureVersion 2 (Subject 포함)",
+			build: func() *Message {
+				m := newMessage("2")
+				m.Subject = "Amazon SES Email Event Notification"
+				signMessage(t, key, m)
+				return m
+			},
+		},
+		{
+			name: "구독 확인 메시지",
+			build: func() *Message {
+				m := newMessage("2")
+				m.Type = TypeSubscriptionConfirmation
+				m.Token = "token"
+				m.SubscribeURL = "https://sns.ap-northeast-2.amazonaws.com/?Action=ConfirmSubscription"
+				signMessage(t, key, m)
+				return m
+			},
+		},
+		{
+			name: "서명 후 본문 변조",
+			build: func() *Message {
+				m := newMessage("2")
+				signMessage(t, key, m)
+				m.Message = `{"notificationType":"Bounce"}`
+				return m
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "서명 없음",
+			build: func() *Message {
+				return newMessage("2")
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "지원하지 않는 서명 버전",
+			build: func() *Message {
+				m := newMessage("3")
+				m.Signature = "c2ln"
+				return m
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "허용되지 않은 토픽",
+			build: func() *Message {
+				m := newMessage("2")
+				m.TopicArn = "arn:aws:sns:ap-northeast-2:999999999999:attacker"
+				signMessage(t, key, m)
+				return m
+			},
+			wantErr: ErrTopicNotAllowed,
+		},
+		{
+			name: "SNS 도메인이 아닌 인증서 URL",
+			build: func() *Message {
+				m := newMessage("2")
+				m.SigningCertURL = "https://sns.ap-northeast-2.amazonaws.com.evil.example/cert.pem"
+				signMessage(t, key, m)
+				return m
+			},
+			wantErr: ErrInvalidCertURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewVerifier(&fakeCertFetcher{cert: cert}, []string{topic})
+			err := v.Verify(context.Background(), tt.build())
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Verify() 에러 = %v, 예상 = nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() 에러 = %v, 예상 = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestVerifyCachesCertificate 서명 인증서는 URL별로 한 번만 조회
+func TestVerifyCachesCertificate(t *testing.T) {
+	key, cert := newTestSigner(t)
+	fetcher := &fakeCertFetcher{cert: cert}
+	v := NewVerifier(fetcher, []string{"arn:aws:sns:us-east-1:123456789012:any"})
+
+	for i := 0; i < 3; i++ {
+		m := &Message{
+			Type:             TypeNotification,
+			MessageId:        "msg",
+			TopicArn:         "arn:aws:sns:us-east-1:123456789012:any",
+			Message:          "{}",
+			Timestamp:        "2024-01-01T00:00:00.000Z",
+			SignatureVersion: "2",
+			SigningCertURL:   testCertURL,
+		}
+		signMessage(t, key, m)
+		if err := v.Verify(context.Background(), m); err != nil {
+			t.Fatalf("Verify() 에러 = %v", err)
+		}
+	}
+	if fetcher.calls != 1 {
+		t.Errorf("인증서 조회 횟수 = %d, 예상 = 1", fetcher.calls)
+	}
+}
+
+// TestVerifyWithoutTopics 허용 토픽이 없으면 알림은 거부하고 구독 메시지만 서명 검증 후 허용
+func TestVerifyWithoutTopics(t *testing.T) {
+	key, cert := newTestSigner(t)
+	v := NewVerifier(&fakeCertFetcher{cert: cert}, nil)
+
+	tests := []struct {
+		msgType string // 메시지 유형
+		wantErr error  // 예상 에러
+	}{
+		{TypeNotification, ErrTopicNotAllowed},
+		{TypeSubscriptionConfirmation, nil},
+		{TypeUnsubscribeConfirmation, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msgType, func(t *testing.T) {
+			m := &Message{
+				Type:             tt.msgType,
+				MessageId:        "msg",
+				TopicArn:         "arn:aws:sns:us-east-1:123456789012:any",
+				Message:          "{}",
+				SubscribeURL:     "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+				Token:            "token",
+				Timestamp:        "2024-01-01T00:00:00.000Z",
+				SignatureVersion: "2",
+				SigningCertURL:   testCertURL,
+			}
+			signMessage(t, key, m)
+			if err := v.Verify(context.Background(), m); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() 에러 = %v, 예상 = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidateCertURL 서명 인증서 URL 검증 테스트
+func TestValidateCertURL(t *testing.T) {
+	tests := []struct {
+		url     string // 인증서 URL
+		wantErr bool   // 에러 발생 예상 여부
+	}{
+		{"https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem", false},
+		{"https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService-abc.pem", false},
+		{"http://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem", true},
+		{"https://sns.us-east-1.amazonaws.com.evil.example/cert.pem", true},
+		{"https://evil.example/sns.us-east-1.amazonaws.com/cert.pem", true},
+		{"https://sns.us-east-1.amazonaws.com:8443/cert.pem", true},
+		{"https://user@sns.us-east-1.amazonaws.com/cert.pem", true},
+		{"https://sns.us-east-1.amazonaws.com/cert.txt", true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateCertURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateCertURL(%q) 에러 = %v, 예상 에러 여부 = %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package tracking
+
+import (
+	"net"
+	"strings"
+)
+
+// 열람 클라이언트 분류
+const (
+	KindHuman = "human" // 수신자가 직접 연 경우
+	KindProxy = "proxy" // 메일 서비스의 이미지 프록시 또는 사전 로딩
+	KindBot   = "bot"   // 보안 스캐너, 크롤러 등 자동화 도구
+)
+
+// Client 추적 요청 클라이언트 분류 결과
+type Client struct {
+	Kind   string `json:"kind"`             // human, proxy, bot
+	Source string `json:"source,omitempty"` // 식별된 프록시 또는 봇 이름
+}
+
+// Machine 사람이 아닌 자동 요청 여부
+func (c Client) Machine() bool {
+	return c.Kind != KindHuman
+}
+
+// proxyAgents 메일 서비스 이미지 프록시 User-Agent (부분 일치, 소문자)
+var proxyAgents = []struct {
+	match  string
+	source string
+}{
+	{"googleimageproxy", "gmail_proxy"},
+	{"yahoomailproxy", "yahoo_proxy"},
+	{"ymailproxy", "yahoo_proxy"},
+}
+
+// botAgents 보안 스캐너, 크롤러, HTTP 라이브러리 User-Agent (부분 일치, 소문자)
+var botAgents = []string{
+	"bot", "crawler", "spider", "scanner", "preview", "headlesschrome",
+	"curl", "wget", "python-requests", "python-urllib", "go-http-client", "java/", "okhttp",
+	"barracuda", "proofpoint", "mimecast", "symantec", "messagelabs", "trendmicro", "fortiguard",
+}
+
+// appleNetwork Apple 소유 IP 대역 (Mail Privacy Protection 사전 로딩 프록시 포함)
+var appleNetwork = &net.IPNet{IP: net.IPv4(17, 0, 0, 0), Mask: net.CIDRMask(8, 32)}
+
+// Classify User-Agent와 IP로 추적 요청 클라이언트 분류
+func Classify(userAgent, ip string) Client {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+
+	for _, p := range proxyAgents {
+		if strings.Contains(ua, p.match) {
+			return Client{Kind: KindProxy, Source: p.source}
+		}
+	}
+
+	// Apple Mail Privacy Protection은 최소한의 User-Agent로 Apple 대역에서 미리 이미지를 가져옴
+	if parsed := net.ParseIP(ip); parsed != nil && appleNetwork.Contains(parsed) {
+		return Client{Kind: KindProxy, Source: "apple_mpp"}
+	}
+	if ua == "mozilla/5.0" {
+		return Client{Kind: KindProxy, Source: "apple_mpp"}
+	}
+
+	if ua == "" {
+		return Client{Kind: KindBot, Source: "empty_user_agent"}
+	}
+	for _, b := range botAgents {
+		if strings.Contains(ua, b) {
+			return Client{Kind: KindBot, Source: b}
+		}
+	}
+	return Client{Kind: KindHuman}
+}
+
+// ClientIP 요청 원격 주소에서 IP 추출 (RealIP 미들웨어 적용 시 포트 없는 IP)
+func ClientIP(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
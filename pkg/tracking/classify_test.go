@@ -0,0 +1,93 @@
+package tracking
+
+import "testing"
+
+// TestClassify 열람 클라이언트 분류 테스트
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string // 테스트 케이스 이름
+		userAgent string // User-Agent
+		ip        string // 클라이언트 IP
+		want      Client // 예상 분류
+	}{
+		{
+			name:      "일반 브라우저",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko)",
+			ip:        "203.0.113.10",
+			want:      Client{Kind: KindHuman},
+		},
+		{
+			name:      "Gmail 이미지 프록시",
+			userAgent: "Mozilla/5.0 (Windows NT 5.1; rv:11.0) Gecko Firefox/11.0 (via ggpht.com GoogleImageProxy)",
+			ip:        "66.249.84.1",
+			want:      Client{Kind: KindProxy, Source: "gmail_proxy"},
+		},
+		{
+			name:      "Yahoo 이미지 프록시",
+			userAgent: "YahooMailProxy; https://help.yahoo.com/kb/yahoo-mail-proxy-SLN28749.html",
+			ip:        "98.136.1.1",
+			want:      Client{Kind: KindProxy, Source: "yahoo_proxy"},
+		},
+		{
+			name:      "Apple 대역 IP",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko)",
+			ip:        "17.58.100.1",
+			want:      Client{Kind: KindProxy, Source: "apple_mpp"},
+		},
+		{
+			name:      "Apple Mail Privacy Protection User-Agent",
+			userAgent: "Mozilla/5.0",
+			ip:        "104.28.1.1",
+			want:      Client{Kind: KindProxy, Source: "apple_mpp"},
+		},
+		{
+			name:      "보안 스캐너",
+			userAgent: "Barracuda Sentinel (EE)",
+			ip:        "203.0.113.20",
+			want:      Client{Kind: KindBot, Source: "barracuda"},
+		},
+		{
+			name:      "HTTP 라이브러리",
+			userAgent: "python-requests/2.31.0",
+			ip:        "203.0.113.30",
+			want:      Client{Kind: KindBot, Source: "python-requests"},
+		},
+		{
+			name:      "User-Agent 없음",
+			userAgent: "",
+			ip:        "203.0.113.40",
+			want:      Client{Kind: KindBot, Source: "empty_user_agent"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Classify(tt.userAgent, tt.ip)
+			if got != tt.want {
+				t.Errorf("Classify() = %+v, 예상 = %+v", got, tt.want)
+			}
+			if got.Machine() != (tt.want.Kind != KindHuman) {
+				t.Errorf("Machine() = %v, 예상 = %v", got.Machine(), tt.want.Kind != KindHuman)
+			}
+		})
+	}
+}
+
+// TestClientIP 원격 주소에서 IP 추출 테스트
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		remoteAddr string // 원격 주소
+		want       string // 예상 IP
+	}{
+		{"203.0.113.1:54321", "203.0.113.1"},
+		{"203.0.113.1", "203.0.113.1"},
+		{"[2001:db8::1]:443", "2001:db8::1"},
+		{"2001:db8::1", "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		if got := ClientIP(tt.remoteAddr); got != tt.want {
+			t.Errorf("ClientIP(%q) = %q, 예상 = %q", tt.remoteAddr, got, tt.want)
+		}
+	}
+}
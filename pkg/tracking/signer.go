@@ -0,0 +1,75 @@
+package tracking
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// 추적 이벤트 유형
+const (
+	EventOpen        = "open"
+	EventClick       = "click"
+	EventUnsubscribe = "unsubscribe" // 수신 거부 URL (List-Unsubscribe)
+	EventPreferences = "preferences" // 구독 환경 설정 페이지 토큰
+)
+
+// signatureSize 서명 길이 (HMAC-SHA256 앞 16바이트)
+const signatureSize = 16
+
+// Signer 추적 URL 서명 및 검증 (첫 번째 키로 서명, 모든 키로 검증)
+type Signer struct {
+	keys [][]byte
+}
+
+// NewSigner 서명 키 목록으로 Signer 생성 (키 교체 시 새 키를 앞에 두고 이전 키는 만료될 때까지 유지)
+func NewSigner(keys ...string) (*Signer, error) {
+	s := &Signer{}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		s.keys = append(s.keys, []byte(key))
+	}
+	if len(s.keys) == 0 {
+		return nil, errors.New("at least one tracking key is required")
+	}
+	return s, nil
+}
+
+// Sign 요청 ID와 이벤트 유형에 대한 서명 생성 (URL에 그대로 사용 가능한 문자열)
+func (s *Signer) Sign(event string, requestID uint) string {
+	return base64.RawURLEncoding.EncodeToString(s.mac(s.keys[0], event, strconv.FormatUint(uint64(requestID), 10)))
+}
+
+// Verify 서명 검증 (등록된 키 중 하나라도 일치하면 유효)
+func (s *Signer) Verify(event string, requestID uint, signature string) bool {
+	return s.verify(event, strconv.FormatUint(uint64(requestID), 10), signature)
+}
+
+// verify 이벤트 유형과 값에 대한 서명 검증 (등록된 키 중 하나라도 일치하면 유효)
+func (s *Signer) verify(event, value, signature string) bool {
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || len(sig) != signatureSize {
+		return false
+	}
+	for _, key := range s.keys {
+		if hmac.Equal(sig, s.mac(key, event, value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// mac 이벤트 유형과 값(요청 ID, 토큰 내용)의 HMAC 계산
+func (s *Signer) mac(key []byte, event, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(event))
+	h.Write([]byte{':'})
+	h.Write([]byte(value))
+	return h.Sum(nil)[:signatureSize]
+}
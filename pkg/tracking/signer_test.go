@@ -0,0 +1,88 @@
+package tracking
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSignerVerify 추적 URL 서명 검증 테스트
+func TestSignerVerify(t *testing.T) {
+	current, err := NewSigner("new-key", "old-key")
+	if err != nil {
+		t.Fatalf("NewSigner() 에러 = %v", err)
+	}
+	previous, _ := NewSigner("old-key")
+	other, _ := NewSigner("other-key")
+
+	sig := current.Sign(EventOpen, 42)
+
+	tests := []struct {
+		name      string // 테스트 케이스 이름
+		event     string // 이벤트 유형
+		requestID uint   // 요청 ID
+		signature string // 검증할 서명
+		want      bool   // 예상 검증 결과
+	}{
+		{"유효한 서명", EventOpen, 42, sig, true},
+		{"이전 키로 서명된 URL", EventOpen, 42, previous.Sign(EventOpen, 42), true},
+		{"다른 요청 ID", EventOpen, 43, sig, false},
+		{"다른 이벤트 유형", EventClick, 42, sig, false},
+		{"등록되지 않은 키", EventOpen, 42, other.Sign(EventOpen, 42), false},
+		{"서명 없음", EventOpen, 42, "", false},
+		{"잘못된 인코딩", EventOpen, 42, "%%%", false},
+		{"잘린 서명", EventOpen, 42, sig[:10], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := current.Verify(tt.event, tt.requestID, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, 예상 = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewSignerRequiresKey 키가 없으면 Signer 생성 실패
+func TestNewSignerRequiresKey(t *testing.T) {
+	if _, err := NewSigner("", " "); err == nil {
+		t.Error("NewSigner() 에러가 예상되었지만 nil")
+	}
+}
+
+// TestToken 서명된 토큰 생성과 검증 테스트 (변조, 만료, 다른 이벤트, 키 교체)
+func TestToken(t *testing.T) {
+	previous, _ := NewSigner("old-key")
+	current, _ := NewSigner("new-key", "old-key")
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	token := current.Token(EventPreferences, "user@example.com", now.Add(time.Hour))
+
+	tests := []struct {
+		name    string    // 테스트 케이스 이름
+		event   string    // 검증할 이벤트 유형
+		token   string    // 검증할 토큰
+		now     time.Time // 검증 시각
+		want    string    // 예상 대상
+		wantErr error     // 예상 에러
+	}{
+		{"유효한 토큰", EventPreferences, token, now, "user@example.com", nil},
+		{"이전 키로 서명된 토큰", EventPreferences, previous.Token(EventPreferences, "a@example.com", now.Add(time.Hour)), now, "a@example.com", nil},
+		{"만료된 토큰", EventPreferences, token, now.Add(time.Hour), "", ErrTokenExpired},
+		{"다른 이벤트 유형", EventUnsubscribe, token, now, "", ErrInvalidToken},
+		{"변조된 대상", EventPreferences, strings.Replace(token, token[:4], "AAAA", 1), now, "", ErrInvalidToken},
+		{"서명 없음", EventPreferences, strings.Split(token, ".")[0], now, "", ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := current.ParseToken(tt.event, tt.token, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseToken() 에러 = %v, 예상 = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseToken() = %q, 예상 = %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package tracking
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken 토큰 형식이 잘못되었거나 서명이 일치하지 않는 경우
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired 토큰 유효 기간이 지난 경우
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Token 대상(수신 주소 등)과 만료 시각을 담은 서명된 토큰 생성 (URL에 그대로 사용 가능한 문자열)
+func (s *Signer) Token(event, subject string, expiresAt time.Time) string {
+	payload := subject + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+	sig := base64.RawURLEncoding.EncodeToString(s.mac(s.keys[0], event, payload))
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + sig
+}
+
+// ParseToken 토큰 서명과 만료 시각을 검증하고 대상 반환
+func (s *Signer) ParseToken(event, token string, now time.Time) (string, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	payload := string(raw)
+	if !s.verify(event, payload, sig) {
+		return "", ErrInvalidToken
+	}
+
+	sep := strings.LastIndex(payload, "|")
+	if sep < 0 {
+		return "", ErrInvalidToken
+	}
+	exp, err := strconv.ParseInt(payload[sep+1:], 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if !now.Before(time.Unix(exp, 0)) {
+		return "", ErrTokenExpired
+	}
+	return payload[:sep], nil
+}